@@ -7,10 +7,14 @@ import (
 	"github.com/gin-gonic/gin"
 	db "github.com/hoangtk0100/simple-bank/db/sqlc"
 	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type createAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	// Optional, checking when empty
+	Product string `json:"product" binding:"omitempty,lowercase,max=32"`
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
@@ -25,9 +29,13 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		Owner:    authPayload.Username,
 		Currency: req.Currency,
 		Balance:  0,
+		Product: pgtype.Text{
+			String: req.Product,
+			Valid:  req.Product != "",
+		},
 	}
 
-	account, err := server.store.CreateAccount(ctx, arg)
+	result, err := server.store.CreateAccountTx(ctx, arg)
 	if err != nil {
 		errCode := db.ErrorCode(err)
 		if errCode == db.ForeignKeyViolation || errCode == db.UniqueViolation {
@@ -39,7 +47,7 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, result.Account)
 }
 
 type getAccountRequest struct {
@@ -65,9 +73,7 @@ func (server *Server) getAccount(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		err := errors.New("the account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	if !server.authorizeAccount(ctx, authPayload.Username, account, db.AccountPermissionView, 0) {
 		return
 	}
 
@@ -75,8 +81,15 @@ func (server *Server) getAccount(ctx *gin.Context) {
 }
 
 type listAccountsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+	// Deprecated: use PageToken instead
+	PageID    int32  `form:"page_id" binding:"omitempty,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=100"`
+	PageToken string `form:"page_token" binding:"omitempty,excluded_with=PageID,page_token"`
+}
+
+type listAccountsResponse struct {
+	Accounts      []db.Account `json:"accounts"`
+	NextPageToken string       `json:"next_page_token"`
 }
 
 func (server *Server) listAccounts(ctx *gin.Context) {
@@ -88,75 +101,154 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	arg := db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Owner: authPayload.Username,
+		Limit: req.PageSize,
+	}
+
+	// page_id is deprecated, its clients keep getting the plain list of the offset pagination
+	if req.PageID > 0 {
+		arg.Offset = (req.PageID - 1) * req.PageSize
+		accounts, err := server.store.ListAccounts(ctx, arg)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, accounts)
+		return
 	}
 
+	// Already validated by the binding
+	arg.AfterID, _ = util.DecodePageToken(req.PageToken)
 	accounts, err := server.store.ListAccounts(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, accounts)
+	rsp := listAccountsResponse{
+		Accounts: accounts,
+	}
+	if len(accounts) > 0 {
+		rsp.NextPageToken = util.NextPageToken(accounts[len(accounts)-1].ID, len(accounts), req.PageSize)
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
-type updateAccountRequestJSON struct {
-	Balance int64 `json:"balance" binding:"required,min=0"`
+type listEntriesRequest struct {
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=100"`
+	PageToken string `form:"page_token" binding:"omitempty,page_token"`
 }
 
-func (server *Server) updateAccount(ctx *gin.Context) {
+type listEntriesResponse struct {
+	Entries       []db.Entry `json:"entries"`
+	NextPageToken string     `json:"next_page_token"`
+}
+
+func (server *Server) listEntries(ctx *gin.Context) {
 	var reqURI getAccountRequest
 	if err := ctx.ShouldBindUri(&reqURI); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	var reqJSON updateAccountRequestJSON
-	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+	var req listEntriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	account, err := server.store.GetAccount(ctx, reqURI.ID)
-	if err != nil {
-		if errors.Is(err, db.ErrRecordNotFound) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
+	account, valid := server.loadAccount(ctx, reqURI.ID)
+	if !valid {
+		return
+	}
 
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !server.authorizeAccount(ctx, authPayload.Username, account, db.AccountPermissionView, 0) {
+		return
+	}
+
+	// Already validated by the binding
+	afterID, _ := util.DecodePageToken(req.PageToken)
+	arg := db.ListEntriesParams{
+		AccountID: account.ID,
+		AfterID:   afterID,
+		Limit:     req.PageSize,
+	}
+
+	entries, err := server.store.ListEntries(ctx, arg)
+	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	rsp := listEntriesResponse{
+		Entries: entries,
+	}
+	if len(entries) > 0 {
+		rsp.NextPageToken = util.NextPageToken(entries[len(entries)-1].ID, len(entries), req.PageSize)
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type updateAccountRequestJSON struct {
+	Balance int64 `json:"balance" binding:"required,min=0"`
+}
+
+func (server *Server) updateAccount(ctx *gin.Context) {
+	var reqURI getAccountRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqJSON updateAccountRequestJSON
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// Owners move money with transfers, only bankers may correct a balance
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		err := errors.New("the account doesn't belong to the authenticated user")
+	if authPayload.Role != util.BankerRole {
+		err := errors.New("only bankers can adjust an account balance")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
-	arg := db.UpdateAccountParams{
-		ID:      account.ID,
-		Balance: reqJSON.Balance,
+	arg := db.AdjustBalanceTxParams{
+		AccountID: reqURI.ID,
+		Balance:   reqJSON.Balance,
 	}
 
-	account, err = server.store.UpdateAccount(ctx, arg)
+	result, err := server.store.AdjustBalanceTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrAccountNotActive) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, result.Account)
 }
 
-type deleteAccountRequest struct {
+type closeAccountRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
-func (server *Server) deleteAccount(ctx *gin.Context) {
-	var req deleteAccountRequest
+// closeAccount closes an account of the authenticated user, the account is kept with its history
+func (server *Server) closeAccount(ctx *gin.Context) {
+	var req closeAccountRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -174,16 +266,32 @@ func (server *Server) deleteAccount(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		err := errors.New("the account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	if !server.authorizeAccount(ctx, authPayload.Username, account, db.AccountPermissionClose, 0) {
 		return
 	}
 
-	err = server.store.DeleteAccount(ctx, account.ID)
+	arg := db.UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    db.AccountStatusClosed,
+		Reason:    "closed by the owner",
+		ChangedBy: authPayload.Username,
+	}
+
+	result, err := server.store.UpdateAccountStatusTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrNonZeroBalance) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrInvalidStatusChange) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	ctx.JSON(http.StatusOK, nil)
+	ctx.JSON(http.StatusOK, result.Account)
 }
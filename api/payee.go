@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+)
+
+// loadActivePayee loads a payee of the user which can be paid
+// The error response is written when it fails
+func (server *Server) loadActivePayee(ctx *gin.Context, username string, payeeID int64) (db.Payee, bool) {
+	payee, err := server.store.GetPayee(ctx, payeeID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return payee, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return payee, false
+	}
+
+	if payee.Owner != username {
+		err := errors.New("the payee doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return payee, false
+	}
+
+	if payee.Status != db.PayeeStatusActive {
+		err := fmt.Errorf("payee is %s, confirm it with the code sent by email first", payee.Status)
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return payee, false
+	}
+
+	return payee, true
+}
+
+// loadPayeeAccount loads the account of an active payee used by a transfer in place of the to account
+// A user payee is paid into their account in the currency of the transfer, which stays private as in a payment
+// The error response is written when it fails
+func (server *Server) loadPayeeAccount(ctx *gin.Context, username string, payeeID int64, fromAccount db.Account) (account db.Account, private bool, valid bool) {
+	payee, valid := server.loadActivePayee(ctx, username, payeeID)
+	if !valid {
+		return account, false, false
+	}
+
+	if payee.ToAccountID.Valid {
+		account, valid = server.loadAccount(ctx, payee.ToAccountID.Int64)
+		return account, false, valid
+	}
+
+	if payee.Currency != fromAccount.Currency {
+		err := fmt.Errorf("payee currency mismatch: %s vs %s", payee.Currency, fromAccount.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return account, true, false
+	}
+
+	account, valid = server.loadRecipientAccount(ctx, payee.ToUsername.String, "", payee.Currency)
+	if !valid {
+		return account, true, false
+	}
+
+	if account.ID == fromAccount.ID {
+		err := errors.New("can not pay yourself")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return account, true, false
+	}
+
+	return account, true, true
+}
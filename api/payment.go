@@ -0,0 +1,210 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/token"
+)
+
+const (
+	paymentStatusCompleted     = "completed"
+	paymentStatusPendingReview = "pending_review"
+)
+
+// errRecipientNotFound is returned for every recipient which can not be paid,
+// so the endpoint does not tell which usernames or emails are registered
+var errRecipientNotFound = errors.New("recipient not found or has no account in this currency")
+
+type paymentRequest struct {
+	FromAccountID     int64             `json:"from_account_id" binding:"required,min=1"`
+	ToUsername        string            `json:"to_username" binding:"required_without_all=ToEmail PayeeID,excluded_with=ToEmail PayeeID"`
+	ToEmail           string            `json:"to_email" binding:"excluded_with=PayeeID,omitempty,email"`
+	Amount            int64             `json:"amount" binding:"required,gt=0"`
+	Currency          string            `json:"currency" binding:"required,currency"`
+	Memo              string            `json:"memo" binding:"max=255"`
+	ExternalReference string            `json:"external_reference" binding:"max=64"`
+	Metadata          map[string]string `json:"metadata" binding:"max=20,dive,keys,min=1,max=40,endkeys,max=500"`
+	// PayeeID is a saved payee of a user, it replaces ToUsername and ToEmail
+	PayeeID int64 `json:"payee_id" binding:"omitempty,min=1"`
+}
+
+// paymentResponse only describes the side of the payer, the account of the recipient is never returned
+type paymentResponse struct {
+	Status            string            `json:"status"`
+	TransferID        int64             `json:"transfer_id,omitempty"`
+	RiskDecisionID    int64             `json:"risk_decision_id,omitempty"`
+	Recipient         string            `json:"recipient"`
+	Amount            int64             `json:"amount"`
+	Currency          string            `json:"currency"`
+	Memo              string            `json:"memo,omitempty"`
+	ExternalReference string            `json:"external_reference,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	FromAccount       db.Account        `json:"from_account"`
+	FromEntry         *db.Entry         `json:"from_entry,omitempty"`
+	Fees              []db.FeeLine      `json:"fees,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+}
+
+func (server *Server) createPayment(ctx *gin.Context) {
+	var req paymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqHeader transferRequestHeader
+	if err := ctx.ShouldBindHeader(&reqHeader); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !server.authorizeAccount(ctx, authPayload.Username, fromAccount, db.AccountPermissionSpend, req.Amount) {
+		return
+	}
+
+	toUsername := req.ToUsername
+	if req.PayeeID != 0 {
+		payee, valid := server.loadActivePayee(ctx, authPayload.Username, req.PayeeID)
+		if !valid {
+			return
+		}
+
+		if !payee.ToUsername.Valid {
+			err := errors.New("the payee is an account, pay it with a transfer instead")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		if payee.Currency != req.Currency {
+			err := fmt.Errorf("payee currency mismatch: %s vs %s", payee.Currency, req.Currency)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		toUsername = payee.ToUsername.String
+	}
+
+	toAccount, valid := server.loadRecipientAccount(ctx, toUsername, req.ToEmail, req.Currency)
+	if !valid {
+		return
+	}
+
+	if toAccount.ID == fromAccount.ID {
+		err := errors.New("can not pay yourself")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// The recipient is recorded as the payer knows them, so the transfer never shows them the account
+	recipient := toUsername
+	if req.ToEmail != "" {
+		recipient = req.ToEmail
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        req.Amount,
+		TransferDetails: db.TransferDetails{
+			Memo:              req.Memo,
+			ExternalReference: req.ExternalReference,
+			Metadata:          req.Metadata,
+			Recipient:         recipient,
+		},
+	}
+
+	if reqHeader.IdempotencyKey != "" {
+		arg.Idempotency = &db.IdempotencyParams{
+			Username: authPayload.Username,
+			Key:      reqHeader.IdempotencyKey,
+			TTL:      server.config.IdempotencyKeyTTL,
+		}
+	}
+
+	outcome, valid := server.sendTransfer(ctx, authPayload.Username, arg, fromAccount, toAccount)
+	if !valid {
+		return
+	}
+
+	rsp := paymentResponse{
+		Recipient:         recipient,
+		Amount:            req.Amount,
+		Currency:          req.Currency,
+		Memo:              req.Memo,
+		ExternalReference: req.ExternalReference,
+		Metadata:          req.Metadata,
+	}
+
+	if outcome.Review != nil {
+		rsp.Status = paymentStatusPendingReview
+		rsp.RiskDecisionID = outcome.Review.RiskDecision.ID
+		rsp.FromAccount = outcome.Review.FromAccount
+		rsp.CreatedAt = outcome.Review.RiskDecision.CreatedAt
+		ctx.JSON(http.StatusAccepted, rsp)
+		return
+	}
+
+	rsp.Status = paymentStatusCompleted
+	rsp.TransferID = outcome.Transfer.Transfer.ID
+	rsp.FromAccount = outcome.Transfer.FromAccount
+	rsp.FromEntry = &outcome.Transfer.FromEntry
+	rsp.Fees = outcome.Transfer.Fees
+	rsp.CreatedAt = outcome.Transfer.Transfer.CreatedAt
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// loadRecipientAccount finds the account in the currency owned by the user with the username or the verified email
+func (server *Server) loadRecipientAccount(ctx *gin.Context, username string, email string, currency string) (db.Account, bool) {
+	if email != "" {
+		user, err := server.store.GetUserByEmail(ctx, email)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				ctx.JSON(http.StatusNotFound, errorResponse(errRecipientNotFound))
+				return db.Account{}, false
+			}
+
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return db.Account{}, false
+		}
+
+		if !user.IsEmailVerified {
+			ctx.JSON(http.StatusNotFound, errorResponse(errRecipientNotFound))
+			return db.Account{}, false
+		}
+
+		username = user.Username
+	}
+
+	// An owner holds at most one account per currency
+	account, err := server.store.GetAccountByOwnerCurrency(ctx, db.GetAccountByOwnerCurrencyParams{
+		Owner:    username,
+		Currency: currency,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errRecipientNotFound))
+			return account, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return account, false
+	}
+
+	if db.IsSystemAccount(account) {
+		ctx.JSON(http.StatusNotFound, errorResponse(errRecipientNotFound))
+		return db.Account{}, false
+	}
+
+	return account, true
+}
@@ -6,17 +6,23 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/hoangtk0100/simple-bank/currency"
 	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/fx"
+	"github.com/hoangtk0100/simple-bank/risk"
 	"github.com/hoangtk0100/simple-bank/token"
 	"github.com/hoangtk0100/simple-bank/util"
 )
 
 // Server serves HTTP request for banking service
 type Server struct {
-	config     util.Config
-	store      db.Store
-	tokenMaker token.Maker
-	router     *gin.Engine
+	config       util.Config
+	store        db.Store
+	tokenMaker   token.Maker
+	rateProvider fx.RateProvider
+	currencies   *currency.Registry
+	riskEngine   *risk.Engine
+	router       *gin.Engine
 }
 
 // NewServer creates a new HTTP server and setup routing
@@ -27,13 +33,17 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 	}
 
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:       config,
+		store:        store,
+		tokenMaker:   tokenMaker,
+		rateProvider: fx.NewStoreRateProvider(store),
+		currencies:   currency.Default(),
+		riskEngine:   risk.NewEngine(risk.DefaultRules(store, config)...),
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("currency", validCurrency(server.currencies))
+		v.RegisterValidation("page_token", validPageToken)
 	}
 
 	server.setupRouter()
@@ -54,10 +64,15 @@ func (server *Server) setupRouter() {
 	authGroup.POST("/accounts", server.createAccount)
 	authGroup.GET("/accounts/:id", server.getAccount)
 	authGroup.GET("/accounts", server.listAccounts)
+	authGroup.GET("/accounts/:id/statement", server.getAccountStatement)
+	authGroup.GET("/accounts/:id/entries", server.listEntries)
 	authGroup.PUT("/accounts/:id", server.updateAccount)
-	authGroup.DELETE("/accounts/:id", server.deleteAccount)
+	authGroup.DELETE("/accounts/:id", server.closeAccount)
 
 	authGroup.POST("/transfers", server.createTransfer)
+	authGroup.GET("/transfers", server.listTransfers)
+
+	authGroup.POST("/payments", server.createPayment)
 
 	server.router = router
 }
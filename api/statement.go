@@ -0,0 +1,72 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/statement"
+	"github.com/hoangtk0100/simple-bank/token"
+)
+
+type getAccountStatementRequest struct {
+	FromDate time.Time `form:"from_date" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	ToDate   time.Time `form:"to_date" binding:"required,gtefield=FromDate" time_format:"2006-01-02" time_utc:"1"`
+	Format   string    `form:"format" binding:"required,oneof=csv pdf"`
+}
+
+func (server *Server) getAccountStatement(ctx *gin.Context) {
+	var reqURI getAccountRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req getAccountStatementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAccount(ctx, reqURI.ID)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !server.authorizeAccount(ctx, authPayload.Username, account, db.AccountPermissionView, 0) {
+		return
+	}
+
+	result, err := server.store.AccountStatementTx(ctx, db.AccountStatementTxParams{
+		AccountID: account.ID,
+		FromTime:  req.FromDate,
+		ToTime:    req.ToDate.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	cur, ok := server.currencies.Get(result.Account.Currency)
+	if !ok {
+		err := fmt.Errorf("unknown currency %s", result.Account.Currency)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	format := statement.Format(req.Format)
+	accountStatement := statement.New(result, cur, req.FromDate, req.ToDate)
+
+	var buf bytes.Buffer
+	if err := accountStatement.Write(&buf, format); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", accountStatement.FileName(format)))
+	ctx.Data(http.StatusOK, statement.ContentType(format), buf.Bytes())
+}
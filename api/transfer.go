@@ -6,15 +6,29 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hoangtk0100/simple-bank/currency"
 	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/fx"
+	"github.com/hoangtk0100/simple-bank/risk"
 	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID     int64             `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID       int64             `json:"to_account_id" binding:"required_without=PayeeID,excluded_with=PayeeID,omitempty,min=1"`
+	Amount            int64             `json:"amount" binding:"required,gt=0"`
+	Currency          string            `json:"currency" binding:"required,currency"`
+	Memo              string            `json:"memo" binding:"max=255"`
+	ExternalReference string            `json:"external_reference" binding:"max=64"`
+	Metadata          map[string]string `json:"metadata" binding:"max=20,dive,keys,min=1,max=40,endkeys,max=500"`
+	// PayeeID is a saved payee, it replaces ToAccountID
+	PayeeID int64 `json:"payee_id" binding:"omitempty,min=1"`
+}
+
+type transferRequestHeader struct {
+	IdempotencyKey string `header:"Idempotency-Key" binding:"max=255"`
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -24,38 +38,285 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
+	var reqHeader transferRequestHeader
+	if err := ctx.ShouldBindHeader(&reqHeader); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
 	if !valid {
 		return
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if fromAccount.Owner != authPayload.Username {
-		err := errors.New("the from account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	if !server.authorizeAccount(ctx, authPayload.Username, fromAccount, db.AccountPermissionSpend, req.Amount) {
 		return
 	}
 
-	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	// The to account may hold another currency, the amount is converted at the current rate then
+	var toAccount db.Account
+	private := false
+	if req.PayeeID != 0 {
+		toAccount, private, valid = server.loadPayeeAccount(ctx, authPayload.Username, req.PayeeID, fromAccount)
+	} else {
+		toAccount, valid = server.loadAccount(ctx, req.ToAccountID)
+	}
 	if !valid {
 		return
 	}
 
 	arg := db.TransferTxParams{
 		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
+		ToAccountID:   toAccount.ID,
 		Amount:        req.Amount,
+		TransferDetails: db.TransferDetails{
+			Memo:              req.Memo,
+			ExternalReference: req.ExternalReference,
+			Metadata:          req.Metadata,
+		},
+	}
+
+	// A user payee is paid like a payment, so the account looked up for the sender stays private
+	if private {
+		arg.Recipient = toAccount.Owner
+	}
+
+	if reqHeader.IdempotencyKey != "" {
+		arg.Idempotency = &db.IdempotencyParams{
+			Username: authPayload.Username,
+			Key:      reqHeader.IdempotencyKey,
+			TTL:      server.config.IdempotencyKeyTTL,
+		}
+	}
+
+	outcome, valid := server.sendTransfer(ctx, authPayload.Username, arg, fromAccount, toAccount)
+	if !valid {
+		return
+	}
+
+	if outcome.Review != nil {
+		ctx.JSON(http.StatusAccepted, outcome.Review)
+		return
+	}
+
+	// Only the side of the sender is returned when the account of a user payee was looked up for them
+	if private {
+		ctx.JSON(http.StatusOK, payeeTransferResponse{
+			Transfer:    db.RedactRecipient(outcome.Transfer.Transfer, fromAccount.ID),
+			FromAccount: outcome.Transfer.FromAccount,
+			FromEntry:   outcome.Transfer.FromEntry,
+			Fees:        outcome.Transfer.Fees,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, outcome.Transfer)
+}
+
+// payeeTransferResponse is a transfer to a user payee, without the account and the entry of the recipient
+type payeeTransferResponse struct {
+	Transfer    db.Transfer  `json:"transfer"`
+	FromAccount db.Account   `json:"from_account"`
+	FromEntry   db.Entry     `json:"from_entry"`
+	Fees        []db.FeeLine `json:"fees"`
+}
+
+// transferOutcome is either the completed transfer or the hold on its funds when the fraud screening asked for a review
+type transferOutcome struct {
+	Transfer *db.TransferTxResult
+	Review   *db.HoldTransferForReviewTxResult
+}
+
+// sendTransfer screens the transfer then moves the money, converting the amount when the accounts hold different currencies
+// The error response is written when it fails
+func (server *Server) sendTransfer(
+	ctx *gin.Context,
+	username string,
+	arg db.TransferTxParams,
+	fromAccount db.Account,
+	toAccount db.Account,
+) (transferOutcome, bool) {
+	screening, err := server.riskEngine.Screen(ctx, server.store, risk.ScreenParams{
+		Transfer: risk.Transfer{
+			Username:    username,
+			FromAccount: fromAccount,
+			ToAccount:   toAccount,
+			Amount:      arg.Amount,
+		},
+		Request:      arg,
+		HoldDuration: server.config.RiskReviewHoldDuration,
+	})
+	if err != nil {
+		ctx.JSON(screeningStatus(err), errorResponse(err))
+		return transferOutcome{}, false
 	}
 
-	result, err := server.store.TransferTx(ctx, arg)
+	if screening.Allowed == nil {
+		return transferOutcome{Transfer: screening.Transfer, Review: screening.Review}, true
+	}
+
+	arg = *screening.Allowed
+
+	var result db.TransferTxResult
+	if toAccount.Currency == fromAccount.Currency {
+		result, err = server.store.TransferTx(ctx, arg)
+	} else {
+		crossArg, valid := server.crossCurrencyTransferParams(ctx, arg, fromAccount.Currency, toAccount.Currency)
+		if !valid {
+			return transferOutcome{}, false
+		}
+
+		result, err = server.store.CrossCurrencyTransferTx(ctx, crossArg)
+	}
 	if err != nil {
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return transferOutcome{}, false
+		}
+
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountNotActive) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return transferOutcome{}, false
+		}
+
+		if errors.Is(err, db.ErrSystemAccount) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return transferOutcome{}, false
+		}
+
+		if errors.Is(err, db.ErrTransferLimitExceeded) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return transferOutcome{}, false
+		}
+
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return transferOutcome{}, false
 	}
 
-	ctx.JSON(http.StatusOK, result)
+	return transferOutcome{Transfer: &result}, true
 }
 
-func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+// screeningStatus is the HTTP status of an error of the fraud screening, including the hold of a transfer sent for review
+func screeningStatus(err error) int {
+	switch {
+	case errors.Is(err, risk.ErrTransferBlocked), errors.Is(err, db.ErrSystemAccount):
+		return http.StatusForbidden
+	case errors.Is(err, db.ErrIdempotencyKeyConflict):
+		return http.StatusConflict
+	case errors.Is(err, db.ErrInsufficientFunds), errors.Is(err, db.ErrAccountNotActive):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
+type listTransfersRequest struct {
+	AccountID         int64  `form:"account_id" binding:"required,min=1"`
+	PageSize          int32  `form:"page_size" binding:"required,min=5,max=100"`
+	PageToken         string `form:"page_token" binding:"omitempty,page_token"`
+	ExternalReference string `form:"external_reference" binding:"max=64"`
+}
+
+type listTransfersResponse struct {
+	Transfers     []db.Transfer `json:"transfers"`
+	NextPageToken string        `json:"next_page_token"`
+}
+
+func (server *Server) listTransfers(ctx *gin.Context) {
+	var req listTransfersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.loadAccount(ctx, req.AccountID)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !server.authorizeAccount(ctx, authPayload.Username, account, db.AccountPermissionView, 0) {
+		return
+	}
+
+	// Already validated by the binding
+	afterID, _ := util.DecodePageToken(req.PageToken)
+	arg := db.ListTransfersParams{
+		FromAccountID: account.ID,
+		ToAccountID:   account.ID,
+		AfterID:       afterID,
+		ExternalReference: pgtype.Text{
+			String: req.ExternalReference,
+			Valid:  req.ExternalReference != "",
+		},
+		Limit: req.PageSize,
+	}
+
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// The account of the recipient of a payment is hidden from the payer
+	for index := range transfers {
+		transfers[index] = db.RedactRecipient(transfers[index], account.ID)
+	}
+
+	rsp := listTransfersResponse{
+		Transfers: transfers,
+	}
+	if len(transfers) > 0 {
+		rsp.NextPageToken = util.NextPageToken(transfers[len(transfers)-1].ID, len(transfers), req.PageSize)
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// crossCurrencyTransferParams quotes the current rate and converts the amount into the currency of the to account
+func (server *Server) crossCurrencyTransferParams(
+	ctx *gin.Context,
+	arg db.TransferTxParams,
+	fromCurrency string,
+	toCurrency string,
+) (db.CrossCurrencyTransferTxParams, bool) {
+	quote, err := server.rateProvider.GetQuote(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		if errors.Is(err, fx.ErrRateNotFound) {
+			err := fmt.Errorf("no exchange rate from %s to %s", fromCurrency, toCurrency)
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return db.CrossCurrencyTransferTxParams{}, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.CrossCurrencyTransferTxParams{}, false
+	}
+
+	from, fromFound := server.currencies.Get(fromCurrency)
+	to, toFound := server.currencies.Get(toCurrency)
+	if !fromFound || !toFound {
+		err := fmt.Errorf("unknown currency %s or %s", fromCurrency, toCurrency)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.CrossCurrencyTransferTxParams{}, false
+	}
+
+	toAmount, err := quote.Convert(currency.NewMoney(arg.Amount, from), to)
+	if err != nil || toAmount.Amount <= 0 {
+		err := fmt.Errorf("amount can not be converted from %s to %s", fromCurrency, toCurrency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.CrossCurrencyTransferTxParams{}, false
+	}
+
+	return db.CrossCurrencyTransferTxParams{
+		TransferTxParams: arg,
+		ToAmount:         toAmount.Amount,
+		ExchangeRate:     quote.Rate,
+		QuoteID:          quote.ID,
+	}, true
+}
+
+func (server *Server) loadAccount(ctx *gin.Context, accountID int64) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
@@ -67,6 +328,15 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 		return account, false
 	}
 
+	return account, true
+}
+
+func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+	account, valid := server.loadAccount(ctx, accountID)
+	if !valid {
+		return account, false
+	}
+
 	if account.Currency != currency {
 		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", accountID, account.Currency, currency)
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
@@ -75,3 +345,30 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 
 	return account, true
 }
+
+// authorizeAccount makes sure the user is an active member of the account with the given permission
+// The error response is written when it fails
+func (server *Server) authorizeAccount(ctx *gin.Context, username string, account db.Account, permission string, amount int64) bool {
+	err := db.AuthorizeAccount(ctx, server.store, db.AuthorizeAccountParams{
+		Account:    account,
+		Username:   username,
+		Permission: permission,
+		Amount:     amount,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAccountAccessDenied) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return false
+		}
+
+		if errors.Is(err, db.ErrSpendLimitExceeded) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	return true
+}
@@ -2,13 +2,27 @@ package api
 
 import (
 	"github.com/go-playground/validator/v10"
+	"github.com/hoangtk0100/simple-bank/currency"
 	"github.com/hoangtk0100/simple-bank/util"
 )
 
-var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
-	if currency, ok := fieldLevel.Field().Interface().(string); ok {
-		// Check currency is supported
-		return util.IsSupportedCurrency(currency)
+// validCurrency accepts the currencies which are enabled in the registry
+func validCurrency(registry *currency.Registry) validator.Func {
+	return func(fieldLevel validator.FieldLevel) bool {
+		if code, ok := fieldLevel.Field().Interface().(string); ok {
+			// Check currency is supported
+			return registry.IsSupported(code)
+		}
+
+		return false
+	}
+}
+
+// validPageToken accepts the tokens returned as next_page_token by the list endpoints
+var validPageToken validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if token, ok := fieldLevel.Field().Interface().(string); ok {
+		_, err := util.DecodePageToken(token)
+		return err == nil
 	}
 
 	return false
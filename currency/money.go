@@ -0,0 +1,84 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Money is an amount expressed in the minor unit of its currency, e.g. cents for USD and yen for JPY
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// ParseMoney converts a decimal string like "12.34" to minor units
+// It rejects more decimals than the currency exponent allows instead of rounding them
+func ParseMoney(value string, currency Currency) (Money, error) {
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign = "-"
+		value = value[1:]
+	}
+
+	whole, fraction, hasFraction := strings.Cut(value, ".")
+	if whole == "" || (hasFraction && fraction == "") || len(fraction) > int(currency.Exponent) {
+		return Money{}, ErrInvalidAmount
+	}
+
+	fraction += strings.Repeat("0", int(currency.Exponent)-len(fraction))
+	for _, digit := range whole + fraction {
+		if digit < '0' || digit > '9' {
+			return Money{}, ErrInvalidAmount
+		}
+	}
+
+	amount, err := strconv.ParseInt(sign+whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidAmount
+	}
+
+	return NewMoney(amount, currency), nil
+}
+
+// Decimal formats the amount in major units with exactly Exponent decimals, e.g. "12.30"
+func (money Money) Decimal() string {
+	digits := strconv.FormatInt(money.Amount, 10)
+	sign := ""
+	if money.Amount < 0 {
+		sign = "-"
+		digits = digits[1:]
+	}
+
+	exponent := int(money.Currency.Exponent)
+	if exponent == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	split := len(digits) - exponent
+	return fmt.Sprintf("%s%s.%s", sign, digits[:split], digits[split:])
+}
+
+// String formats the amount with the currency symbol, e.g. "$12.30" or "-¥500"
+func (money Money) String() string {
+	value := money.Decimal()
+	if strings.HasPrefix(value, "-") {
+		return "-" + money.Currency.Symbol + value[1:]
+	}
+
+	return money.Currency.Symbol + value
+}
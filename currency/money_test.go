@@ -0,0 +1,84 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	usd = Currency{Code: "USD", NumericCode: 840, Exponent: 2, Symbol: "$", Enabled: true}
+	jpy = Currency{Code: "JPY", NumericCode: 392, Exponent: 0, Symbol: "¥", Enabled: true}
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		value    string
+		currency Currency
+		amount   int64
+		err      error
+	}{
+		{value: "12.34", currency: usd, amount: 1234},
+		{value: "12.3", currency: usd, amount: 1230},
+		{value: "12", currency: usd, amount: 1200},
+		{value: "0.05", currency: usd, amount: 5},
+		{value: "-0.05", currency: usd, amount: -5},
+		{value: "500", currency: jpy, amount: 500},
+		{value: "12.345", currency: usd, err: ErrInvalidAmount},
+		{value: "500.5", currency: jpy, err: ErrInvalidAmount},
+		{value: "12.", currency: usd, err: ErrInvalidAmount},
+		{value: ".5", currency: usd, err: ErrInvalidAmount},
+		{value: "1,000", currency: usd, err: ErrInvalidAmount},
+		{value: "", currency: usd, err: ErrInvalidAmount},
+	}
+
+	for _, tc := range testCases {
+		money, err := ParseMoney(tc.value, tc.currency)
+		require.ErrorIs(t, err, tc.err, tc.value)
+		require.Equal(t, tc.amount, money.Amount, tc.value)
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	testCases := []struct {
+		money   Money
+		decimal string
+		str     string
+	}{
+		{money: NewMoney(1234, usd), decimal: "12.34", str: "$12.34"},
+		{money: NewMoney(5, usd), decimal: "0.05", str: "$0.05"},
+		{money: NewMoney(-1230, usd), decimal: "-12.30", str: "-$12.30"},
+		{money: NewMoney(0, usd), decimal: "0.00", str: "$0.00"},
+		{money: NewMoney(500, jpy), decimal: "500", str: "¥500"},
+		{money: NewMoney(-500, jpy), decimal: "-500", str: "-¥500"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.decimal, tc.money.Decimal())
+		require.Equal(t, tc.str, tc.money.String())
+
+		parsed, err := ParseMoney(tc.decimal, tc.money.Currency)
+		require.NoError(t, err)
+		require.Equal(t, tc.money, parsed)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(usd, jpy)
+	require.True(t, registry.IsSupported("USD"))
+	require.False(t, registry.IsSupported("EUR"))
+
+	disabled := jpy
+	disabled.Enabled = false
+	registry.Set(disabled)
+	require.False(t, registry.IsSupported("JPY"))
+
+	currency, ok := registry.Get("JPY")
+	require.True(t, ok)
+	require.Equal(t, disabled, currency)
+
+	currencies := registry.List()
+	require.Len(t, currencies, 2)
+	require.Equal(t, "JPY", currencies[0].Code)
+	require.Equal(t, "USD", currencies[1].Code)
+}
@@ -0,0 +1,151 @@
+package currency
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+// Currency describes an ISO 4217 currency
+type Currency struct {
+	Code        string
+	NumericCode int32
+	// Exponent is the number of digits after the decimal separator, amounts are stored in 10^-Exponent units
+	Exponent int32
+	Symbol   string
+	Enabled  bool
+}
+
+// FromDB converts a row of the currencies table
+func FromDB(currency db.Currency) Currency {
+	return Currency{
+		Code:        currency.Code,
+		NumericCode: currency.NumericCode,
+		Exponent:    currency.Exponent,
+		Symbol:      currency.Symbol,
+		Enabled:     currency.Enabled,
+	}
+}
+
+// Registry keeps the known currencies in memory so validators do not hit the database
+type Registry struct {
+	mutex      sync.RWMutex
+	currencies map[string]Currency
+}
+
+func NewRegistry(currencies ...Currency) *Registry {
+	registry := &Registry{}
+	registry.replace(currencies)
+	return registry
+}
+
+// defaultRegistry starts with the currencies seeded by the migrations, it is reloaded from the database at startup
+var defaultRegistry = NewRegistry(
+	Currency{Code: "USD", NumericCode: 840, Exponent: 2, Symbol: "$", Enabled: true},
+	Currency{Code: "EUR", NumericCode: 978, Exponent: 2, Symbol: "€", Enabled: true},
+	Currency{Code: "CAD", NumericCode: 124, Exponent: 2, Symbol: "CA$", Enabled: true},
+	Currency{Code: "JPY", NumericCode: 392, Exponent: 0, Symbol: "¥", Enabled: true},
+)
+
+// Default returns the registry shared by the whole process
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// IsSupported returns true if the currency is known and enabled in the default registry
+func IsSupported(code string) bool {
+	return defaultRegistry.IsSupported(code)
+}
+
+// Get returns the currency with the given code, even if it is disabled
+func (registry *Registry) Get(code string) (Currency, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	currency, ok := registry.currencies[code]
+	return currency, ok
+}
+
+// IsSupported returns true if new accounts and transfers may use the currency
+func (registry *Registry) IsSupported(code string) bool {
+	currency, ok := registry.Get(code)
+	return ok && currency.Enabled
+}
+
+// Set adds or replaces a single currency
+func (registry *Registry) Set(currency Currency) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.currencies[currency.Code] = currency
+}
+
+// List returns all the currencies sorted by code
+func (registry *Registry) List() []Currency {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	currencies := make([]Currency, 0, len(registry.currencies))
+	for _, currency := range registry.currencies {
+		currencies = append(currencies, currency)
+	}
+
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i].Code < currencies[j].Code
+	})
+	return currencies
+}
+
+// Load replaces the content of the registry with the currencies table
+func (registry *Registry) Load(ctx context.Context, store db.Querier) error {
+	rows, err := store.ListCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	currencies := make([]Currency, 0, len(rows))
+	for _, row := range rows {
+		currencies = append(currencies, FromDB(row))
+	}
+
+	registry.replace(currencies)
+	return nil
+}
+
+// Refresh reloads the registry periodically until the context is cancelled
+// so changes made by bankers through other server instances are picked up
+func (registry *Registry) Refresh(ctx context.Context, store db.Querier, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := registry.Load(ctx, store); err != nil {
+				log.Error().Err(err).Msg("failed to reload currencies")
+			}
+		}
+	}
+}
+
+func (registry *Registry) replace(currencies []Currency) {
+	byCode := make(map[string]Currency, len(currencies))
+	for _, currency := range currencies {
+		byCode[currency.Code] = currency
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.currencies = byCode
+}
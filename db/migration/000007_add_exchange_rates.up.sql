@@ -0,0 +1,26 @@
+CREATE TABLE "exchange_rates" (
+  "from_currency" varchar NOT NULL,
+  "to_currency" varchar NOT NULL,
+  "rate" bigint NOT NULL,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("from_currency", "to_currency")
+);
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'fixed-point, scaled by 10^8';
+
+ALTER TABLE "exchange_rates" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+
+UPDATE "transfers" SET "to_amount" = "amount";
+
+ALTER TABLE "transfers" ALTER COLUMN "to_amount" SET NOT NULL;
+
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" bigint;
+
+ALTER TABLE "transfers" ADD COLUMN "quote_id" varchar;
+
+COMMENT ON COLUMN "transfers"."to_amount" IS 'credited amount in the currency of the to account';
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'fixed-point, scaled by 10^8, null for same-currency transfers';
\ No newline at end of file
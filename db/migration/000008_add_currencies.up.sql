@@ -0,0 +1,25 @@
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  "numeric_code" integer UNIQUE NOT NULL,
+  "exponent" integer NOT NULL,
+  "symbol" varchar NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT ON COLUMN "currencies"."exponent" IS 'number of digits after the decimal separator of the minor unit';
+
+INSERT INTO "currencies" ("code", "numeric_code", "exponent", "symbol") VALUES
+  ('USD', 840, 2, '$'),
+  ('EUR', 978, 2, '€'),
+  ('CAD', 124, 2, 'CA$'),
+  ('JPY', 392, 0, '¥');
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "exchange_rates" ADD FOREIGN KEY ("from_currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "exchange_rates" ADD FOREIGN KEY ("to_currency") REFERENCES "currencies" ("code");
\ No newline at end of file
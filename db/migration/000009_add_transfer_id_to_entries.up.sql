@@ -0,0 +1,18 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+-- The entries of a transfer are written in the same transaction, so they share its now() timestamp
+UPDATE "entries"
+SET "transfer_id" = "transfers"."id"
+FROM "transfers"
+WHERE
+  "entries"."transfer_id" IS NULL AND
+  "entries"."created_at" = "transfers"."created_at" AND (
+    ("entries"."account_id" = "transfers"."from_account_id" AND "entries"."amount" = -"transfers"."amount") OR
+    ("entries"."account_id" = "transfers"."to_account_id" AND "entries"."amount" = "transfers"."to_amount")
+  );
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'null for entries which are not part of a transfer';
\ No newline at end of file
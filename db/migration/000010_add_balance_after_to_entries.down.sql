@@ -0,0 +1 @@
+ALTER TABLE "entries" DROP COLUMN "balance_after";
\ No newline at end of file
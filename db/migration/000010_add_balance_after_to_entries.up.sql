@@ -0,0 +1,22 @@
+ALTER TABLE "entries" ADD COLUMN "balance_after" bigint;
+
+-- Anchored on the current balance rather than on zero, because UpdateAccount may have changed balances without any entry:
+-- the balance after an entry is the account balance minus the amounts of the later entries of the account
+UPDATE "entries"
+SET "balance_after" = "balances"."balance_after"
+FROM (
+  SELECT
+    "entries"."id",
+    "accounts"."balance" - COALESCE(SUM("entries"."amount") OVER (
+      PARTITION BY "entries"."account_id"
+      ORDER BY "entries"."id" DESC
+      ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+    ), 0) AS "balance_after"
+  FROM "entries"
+  JOIN "accounts" ON "accounts"."id" = "entries"."account_id"
+) AS "balances"
+WHERE "entries"."id" = "balances"."id";
+
+ALTER TABLE "entries" ALTER COLUMN "balance_after" SET NOT NULL;
+
+COMMENT ON COLUMN "entries"."balance_after" IS 'balance of the account right after this entry';
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "reconciliation_reports";
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE "reconciliation_reports" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "balance" bigint NOT NULL,
+  "entries_total" bigint NOT NULL,
+  "difference" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "reconciliation_reports" ("account_id");
+
+COMMENT ON COLUMN "reconciliation_reports"."difference" IS 'balance minus entries_total';
+
+ALTER TABLE "reconciliation_reports" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
\ No newline at end of file
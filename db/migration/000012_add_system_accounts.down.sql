@@ -0,0 +1,26 @@
+UPDATE "entries" SET "transfer_id" = NULL
+WHERE "transfer_id" IN (
+  SELECT "transfers"."id" FROM "transfers"
+  JOIN "accounts" ON "accounts"."id" IN ("transfers"."from_account_id", "transfers"."to_account_id")
+  WHERE "accounts"."owner" IN ('system_cash', 'system_fees', 'system_suspense', 'system_fx')
+);
+
+DELETE FROM "entries"
+WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" IN ('system_cash', 'system_fees', 'system_suspense', 'system_fx'));
+
+DELETE FROM "transfers"
+WHERE "from_account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" IN ('system_cash', 'system_fees', 'system_suspense', 'system_fx'))
+  OR "to_account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" IN ('system_cash', 'system_fees', 'system_suspense', 'system_fx'));
+
+DELETE FROM "reconciliation_reports"
+WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" IN ('system_cash', 'system_fees', 'system_suspense', 'system_fx'));
+
+DELETE FROM "accounts" WHERE "owner" IN ('system_cash', 'system_fees', 'system_suspense', 'system_fx');
+
+DELETE FROM "sessions" WHERE "username" IN ('system_cash', 'system_fees', 'system_suspense', 'system_fx');
+
+DELETE FROM "verify_emails" WHERE "username" IN ('system_cash', 'system_fees', 'system_suspense', 'system_fx');
+
+DELETE FROM "users" WHERE "username" IN ('system_cash', 'system_fees', 'system_suspense', 'system_fx');
+
+COMMENT ON COLUMN "users"."role" IS NULL;
\ No newline at end of file
@@ -0,0 +1,8 @@
+COMMENT ON COLUMN "users"."role" IS 'depositor, banker or system, system users own the internal accounts of the ledger';
+
+-- System users own the internal accounts of the ledger, their password hash matches no password so they can not log in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email", "role", "is_email_verified") VALUES
+  ('system_cash', '!', 'Cash', 'system_cash@simple-bank.internal', 'system', true),
+  ('system_fees', '!', 'Fee revenue', 'system_fees@simple-bank.internal', 'system', true),
+  ('system_suspense', '!', 'Suspense', 'system_suspense@simple-bank.internal', 'system', true),
+  ('system_fx', '!', 'FX position', 'system_fx@simple-bank.internal', 'system', true);
\ No newline at end of file
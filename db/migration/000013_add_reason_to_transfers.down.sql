@@ -0,0 +1,3 @@
+ALTER TABLE "transfers" DROP COLUMN "external_reference";
+
+ALTER TABLE "transfers" DROP COLUMN "reason_code";
\ No newline at end of file
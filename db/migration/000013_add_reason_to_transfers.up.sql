@@ -0,0 +1,7 @@
+ALTER TABLE "transfers" ADD COLUMN "reason_code" varchar;
+
+ALTER TABLE "transfers" ADD COLUMN "external_reference" varchar;
+
+COMMENT ON COLUMN "transfers"."reason_code" IS 'why a banker deposited or withdrew the money, null for customer transfers';
+
+COMMENT ON COLUMN "transfers"."external_reference" IS 'reference of the movement in an external system, e.g. a teller receipt';
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "transfers" DROP COLUMN "reversal_of_transfer_id";
\ No newline at end of file
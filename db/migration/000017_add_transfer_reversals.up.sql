@@ -0,0 +1,7 @@
+ALTER TABLE "transfers" ADD COLUMN "reversal_of_transfer_id" bigint;
+
+CREATE INDEX ON "transfers" ("reversal_of_transfer_id");
+
+COMMENT ON COLUMN "transfers"."reversal_of_transfer_id" IS 'transfer refunded in part or in full by this one, which keeps its exchange rate';
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversal_of_transfer_id") REFERENCES "transfers" ("id");
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "transfer_limits";
+
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_idx";
+
+ALTER TABLE "accounts" DROP COLUMN "tier";
\ No newline at end of file
@@ -0,0 +1,43 @@
+ALTER TABLE "accounts" ADD COLUMN "tier" varchar NOT NULL DEFAULT 'standard';
+
+CREATE TABLE "transfer_limits" (
+  "role" varchar NOT NULL,
+  "tier" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "max_amount" bigint NOT NULL,
+  "max_daily_amount" bigint NOT NULL,
+  "max_daily_count" integer NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "tier", "currency")
+);
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+COMMENT ON COLUMN "accounts"."tier" IS 'standard or premium, selects the transfer limits of the account';
+
+COMMENT ON COLUMN "transfer_limits"."role" IS 'role of the owner of the from account';
+
+COMMENT ON COLUMN "transfer_limits"."max_amount" IS 'largest single transfer, in minor units';
+
+COMMENT ON COLUMN "transfer_limits"."max_daily_amount" IS 'largest total sent per UTC day, in minor units';
+
+COMMENT ON COLUMN "transfer_limits"."max_daily_count" IS 'most transfers sent per UTC day';
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+-- The limits are set in major units, so they mean the same for every currency exponent
+INSERT INTO "transfer_limits" ("role", "tier", "currency", "max_amount", "max_daily_amount", "max_daily_count")
+SELECT
+  "limits"."role",
+  "limits"."tier",
+  "currencies"."code",
+  ("limits"."max_amount" * 10 ^ "currencies"."exponent")::bigint,
+  ("limits"."max_daily_amount" * 10 ^ "currencies"."exponent")::bigint,
+  "limits"."max_daily_count"
+FROM (VALUES
+  ('depositor', 'standard', 10000, 20000, 50),
+  ('depositor', 'premium', 100000, 200000, 200),
+  ('banker', 'standard', 100000, 200000, 200),
+  ('banker', 'premium', 1000000, 2000000, 1000)
+) AS "limits" ("role", "tier", "max_amount", "max_daily_amount", "max_daily_count")
+CROSS JOIN "currencies";
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "risk_decisions";
+
+DROP INDEX IF EXISTS "sessions_username_created_at_idx";
\ No newline at end of file
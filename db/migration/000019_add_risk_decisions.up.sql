@@ -0,0 +1,45 @@
+CREATE TABLE "risk_decisions" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "decision" varchar NOT NULL,
+  "rules" varchar[] NOT NULL,
+  "transfer_id" bigint,
+  "hold_id" bigint,
+  "resolution" varchar,
+  "reviewed_by" varchar,
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "risk_decisions" ("from_account_id");
+
+CREATE INDEX ON "risk_decisions" ("decision", "resolution");
+
+CREATE INDEX ON "sessions" ("username", "created_at");
+
+COMMENT ON COLUMN "risk_decisions"."username" IS 'user who requested the transfer';
+
+COMMENT ON COLUMN "risk_decisions"."decision" IS 'allow, review or block';
+
+COMMENT ON COLUMN "risk_decisions"."rules" IS 'names of the fraud rules which triggered';
+
+COMMENT ON COLUMN "risk_decisions"."transfer_id" IS 'transfer made once allowed or approved';
+
+COMMENT ON COLUMN "risk_decisions"."hold_id" IS 'hold on the funds of a transfer waiting for a review';
+
+COMMENT ON COLUMN "risk_decisions"."resolution" IS 'approved or rejected by a banker, null until a review is resolved';
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("hold_id") REFERENCES "holds" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
\ No newline at end of file
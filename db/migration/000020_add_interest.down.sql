@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS "interest_accruals";
+
+UPDATE "entries" SET "transfer_id" = NULL
+WHERE "transfer_id" IN (
+  SELECT "transfers"."id" FROM "transfers"
+  JOIN "accounts" ON "accounts"."id" IN ("transfers"."from_account_id", "transfers"."to_account_id")
+  WHERE "accounts"."owner" = 'system_interest'
+);
+
+DELETE FROM "entries"
+WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" = 'system_interest');
+
+DELETE FROM "transfers"
+WHERE "from_account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" = 'system_interest')
+  OR "to_account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" = 'system_interest');
+
+DELETE FROM "reconciliation_reports"
+WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" = 'system_interest');
+
+DELETE FROM "accounts" WHERE "owner" = 'system_interest';
+
+DELETE FROM "users" WHERE "username" = 'system_interest';
+
+ALTER TABLE "accounts" DROP COLUMN "product";
+
+DROP TABLE IF EXISTS "account_products";
\ No newline at end of file
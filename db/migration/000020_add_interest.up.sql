@@ -0,0 +1,53 @@
+CREATE TABLE "account_products" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "accounts" ADD COLUMN "product" varchar NOT NULL DEFAULT 'checking';
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "amount" bigint NOT NULL,
+  "remainder" bigint NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("transfer_id");
+
+COMMENT ON COLUMN "account_products"."annual_rate_bps" IS 'annual interest rate in basis points, 200 is 2%';
+
+COMMENT ON COLUMN "accounts"."product" IS 'account product, selects the interest rate of the account';
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'balance at the end of the accrual date, in minor units';
+
+COMMENT ON COLUMN "interest_accruals"."annual_rate_bps" IS 'rate of the product on the accrual date';
+
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'interest earned on the accrual date, in minor units';
+
+COMMENT ON COLUMN "interest_accruals"."remainder" IS 'fraction of a minor unit carried to the next accrual, in 1/3650000 of a minor unit';
+
+COMMENT ON COLUMN "interest_accruals"."transfer_id" IS 'interest transfer which paid the accrual, null until the month-end posting';
+
+-- The existing accounts default to the checking product, it must exist before the foreign key
+INSERT INTO "account_products" ("code", "name", "annual_rate_bps") VALUES
+  ('checking', 'Checking', 0),
+  ('savings', 'Savings', 200);
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+-- Pays the interest earned by the customers, its password hash matches no password so it can not log in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email", "role", "is_email_verified") VALUES
+  ('system_interest', '!', 'Interest expense', 'system_interest@simple-bank.internal', 'system', true);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "fee_charges";
+
+DROP TABLE IF EXISTS "fee_schedules";
\ No newline at end of file
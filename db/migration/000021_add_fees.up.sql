@@ -0,0 +1,56 @@
+CREATE TABLE "fee_schedules" (
+  "product" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "monthly_fee" bigint NOT NULL DEFAULT 0,
+  "transfer_fee" bigint NOT NULL DEFAULT 0,
+  "minimum_balance" bigint NOT NULL DEFAULT 0,
+  "below_minimum_fee" bigint NOT NULL DEFAULT 0,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("product", "currency")
+);
+
+CREATE TABLE "fee_charges" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "kind" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "period" date,
+  "transfer_id" bigint,
+  "charged_transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "fee_charges" ("account_id", "kind", "period");
+
+CREATE INDEX ON "fee_charges" ("charged_transfer_id");
+
+COMMENT ON COLUMN "fee_schedules"."monthly_fee" IS 'maintenance fee charged every month, in minor units';
+
+COMMENT ON COLUMN "fee_schedules"."transfer_fee" IS 'fee charged with every transfer sent, in minor units';
+
+COMMENT ON COLUMN "fee_schedules"."minimum_balance" IS 'balance to keep at the end of the month to avoid the below minimum fee, in minor units';
+
+COMMENT ON COLUMN "fee_schedules"."below_minimum_fee" IS 'fee charged when the month ends below the minimum balance, in minor units';
+
+COMMENT ON COLUMN "fee_charges"."kind" IS 'monthly, transfer or below_minimum';
+
+COMMENT ON COLUMN "fee_charges"."amount" IS 'amount actually charged, less than the fee when the account could not pay all of it';
+
+COMMENT ON COLUMN "fee_charges"."period" IS 'first day of the month of a monthly or below minimum fee, null for transfer fees';
+
+COMMENT ON COLUMN "fee_charges"."transfer_id" IS 'transfer to the fee revenue account, null when nothing could be charged';
+
+COMMENT ON COLUMN "fee_charges"."charged_transfer_id" IS 'transfer which incurred a transfer fee';
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "fee_charges" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fee_charges" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "fee_charges" ADD FOREIGN KEY ("charged_transfer_id") REFERENCES "transfers" ("id");
\ No newline at end of file
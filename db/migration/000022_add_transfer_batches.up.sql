@@ -0,0 +1,54 @@
+CREATE TABLE "transfer_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "row_count" integer NOT NULL,
+  "total_amount" bigint NOT NULL,
+  "succeeded_count" integer NOT NULL DEFAULT 0,
+  "failed_count" integer NOT NULL DEFAULT 0,
+  "succeeded_amount" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+CREATE TABLE "transfer_batch_rows" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "row_number" integer NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "reference" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "error" varchar,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfer_batches" ("owner");
+
+CREATE UNIQUE INDEX ON "transfer_batch_rows" ("batch_id", "row_number");
+
+COMMENT ON COLUMN "transfer_batches"."status" IS 'pending until every row is processed, then completed';
+
+COMMENT ON COLUMN "transfer_batches"."total_amount" IS 'sum of the amounts of the rows';
+
+COMMENT ON COLUMN "transfer_batches"."succeeded_count" IS 'summary of the rows, set once the batch is completed';
+
+COMMENT ON COLUMN "transfer_batch_rows"."row_number" IS 'position of the row in the uploaded batch, from 1';
+
+COMMENT ON COLUMN "transfer_batch_rows"."reference" IS 'recorded as the external reference of the transfer, empty for none';
+
+COMMENT ON COLUMN "transfer_batch_rows"."status" IS 'pending, succeeded or failed';
+
+COMMENT ON COLUMN "transfer_batch_rows"."error" IS 'why the transfer of a failed row was rejected';
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_rows" ADD FOREIGN KEY ("batch_id") REFERENCES "transfer_batches" ("id");
+
+ALTER TABLE "transfer_batch_rows" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_rows" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
\ No newline at end of file
@@ -0,0 +1,13 @@
+COMMENT ON COLUMN "transfers"."external_reference" IS 'reference of the movement in an external system, e.g. a teller receipt';
+
+DROP INDEX IF EXISTS "transfers_external_reference_idx";
+
+ALTER TABLE "holds" DROP COLUMN "metadata";
+
+ALTER TABLE "holds" DROP COLUMN "external_reference";
+
+ALTER TABLE "holds" DROP COLUMN "memo";
+
+ALTER TABLE "transfers" DROP COLUMN "metadata";
+
+ALTER TABLE "transfers" DROP COLUMN "memo";
\ No newline at end of file
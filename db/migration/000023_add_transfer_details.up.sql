@@ -0,0 +1,19 @@
+ALTER TABLE "transfers" ADD COLUMN "memo" varchar;
+
+ALTER TABLE "transfers" ADD COLUMN "metadata" jsonb;
+
+ALTER TABLE "holds" ADD COLUMN "memo" varchar;
+
+ALTER TABLE "holds" ADD COLUMN "external_reference" varchar;
+
+ALTER TABLE "holds" ADD COLUMN "metadata" jsonb;
+
+CREATE INDEX ON "transfers" ("external_reference");
+
+COMMENT ON COLUMN "transfers"."external_reference" IS 'reference of the movement in an external system, e.g. a teller receipt or the invoice paid by a customer';
+
+COMMENT ON COLUMN "transfers"."memo" IS 'free text written by the customer who sent the transfer';
+
+COMMENT ON COLUMN "transfers"."metadata" IS 'string map supplied by the client, null when empty';
+
+COMMENT ON COLUMN "holds"."memo" IS 'details of the transfer made when the hold is captured';
\ No newline at end of file
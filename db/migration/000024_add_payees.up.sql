@@ -0,0 +1,29 @@
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "to_account_id" bigint,
+  "to_username" varchar,
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "confirmation_code" varchar,
+  "confirmation_expires_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "nickname");
+
+COMMENT ON COLUMN "payees"."to_account_id" IS 'set when the payee is an account, null when it is a user';
+
+COMMENT ON COLUMN "payees"."to_username" IS 'set when the payee is a user paid into its account in the currency, null when it is an account';
+
+COMMENT ON COLUMN "payees"."status" IS 'pending until the confirmation code is entered, then active';
+
+COMMENT ON COLUMN "payees"."confirmation_code" IS 'code emailed to the owner, null once confirmed';
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
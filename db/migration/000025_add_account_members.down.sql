@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "account_members";
\ No newline at end of file
@@ -0,0 +1,31 @@
+CREATE TABLE "account_members" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "spend_limit" bigint,
+  "status" varchar NOT NULL DEFAULT 'invited',
+  "invited_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "accepted_at" timestamptz
+);
+
+CREATE UNIQUE INDEX ON "account_members" ("account_id", "username");
+
+CREATE INDEX ON "account_members" ("username");
+
+COMMENT ON COLUMN "account_members"."role" IS 'owner, co_owner, viewer or spender';
+
+COMMENT ON COLUMN "account_members"."spend_limit" IS 'largest amount a spender can send in one transfer, null for the other roles';
+
+COMMENT ON COLUMN "account_members"."status" IS 'invited until the user accepts, then active';
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+
+INSERT INTO "account_members" ("account_id", "username", "role", "status", "invited_by", "created_at", "accepted_at")
+SELECT "id", "owner", 'owner', 'active', "owner", "created_at", "created_at"
+FROM "accounts";
\ No newline at end of file
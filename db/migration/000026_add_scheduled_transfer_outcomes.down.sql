@@ -0,0 +1,5 @@
+UPDATE "scheduled_transfers" SET "status" = 'completed' WHERE "status" IN ('dispatched', 'failed');
+
+COMMENT ON COLUMN "scheduled_transfers"."next_run_at" IS 'null once completed or cancelled';
+
+COMMENT ON COLUMN "scheduled_transfers"."status" IS 'active, completed or cancelled';
@@ -0,0 +1,3 @@
+COMMENT ON COLUMN "scheduled_transfers"."status" IS 'active, dispatched, completed, failed or cancelled';
+
+COMMENT ON COLUMN "scheduled_transfers"."next_run_at" IS 'null unless active';
@@ -0,0 +1,3 @@
+DELETE FROM "reconciliation_reports" WHERE "difference" = 0;
+
+COMMENT ON COLUMN "reconciliation_reports"."difference" IS 'balance minus entries_total';
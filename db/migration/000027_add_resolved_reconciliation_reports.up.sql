@@ -0,0 +1 @@
+COMMENT ON COLUMN "reconciliation_reports"."difference" IS 'balance minus entries_total, 0 once an earlier discrepancy is resolved';
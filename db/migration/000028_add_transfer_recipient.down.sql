@@ -0,0 +1,3 @@
+ALTER TABLE "holds" DROP COLUMN "recipient";
+
+ALTER TABLE "transfers" DROP COLUMN "recipient";
@@ -0,0 +1,7 @@
+ALTER TABLE "transfers" ADD COLUMN "recipient" varchar;
+
+ALTER TABLE "holds" ADD COLUMN "recipient" varchar;
+
+COMMENT ON COLUMN "transfers"."recipient" IS 'username or email paid by the sender of a payment, the account of the recipient is only shown to its own side when set';
+
+COMMENT ON COLUMN "holds"."recipient" IS 'recipient of the payment made when the hold is captured';
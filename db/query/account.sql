@@ -1,27 +1,79 @@
 -- name: CreateAccount :one
+-- The account gets the checking product when none is given
 INSERT INTO accounts (
   owner,
   balance,
-  currency
+  currency,
+  product
 ) VALUES (
-  $1, $2, $3
+  sqlc.arg(owner), sqlc.arg(balance), sqlc.arg(currency), COALESCE(sqlc.narg(product)::varchar, 'checking')
 ) RETURNING *;
 
+-- name: CreateSystemAccount :exec
+-- Does nothing when the account already exists
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency
+) VALUES (
+  $1, 0, $2
+) ON CONFLICT ON CONSTRAINT owner_currency_key DO NOTHING;
+
 -- name: GetAccount :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1;
 
+-- name: GetAccountByOwnerCurrency :one
+SELECT * FROM accounts
+WHERE owner = $1 AND currency = $2 LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE;
 
+-- name: ListFeeBearingAccounts :many
+-- Only active accounts can pay, the fees of frozen accounts are waived
+SELECT * FROM accounts
+WHERE
+  status = 'active' AND
+  EXISTS (
+    SELECT 1 FROM fee_schedules
+    WHERE
+      fee_schedules.product = accounts.product AND
+      fee_schedules.currency = accounts.currency AND
+      (fee_schedules.monthly_fee > 0 OR fee_schedules.below_minimum_fee > 0)
+  ) AND
+  id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: ListInterestBearingAccounts :many
+-- Closed accounts have no balance left to earn interest
+SELECT * FROM accounts
+WHERE
+  product IN (SELECT code FROM account_products WHERE annual_rate_bps > 0) AND
+  status <> 'closed' AND
+  id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
 -- name: ListAccounts :many
+-- after_id is the keyset cursor, offset is only used by the deprecated page_id pagination
+-- The accounts shared with the user are listed along with its own ones
 SELECT * FROM accounts
-WHERE owner = $1
+WHERE
+  (
+    owner = sqlc.arg(owner) OR
+    id IN (
+      SELECT account_id FROM account_members
+      WHERE username = sqlc.arg(owner) AND status = 'active'
+    )
+  ) AND
+  id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateAccount :one
 UPDATE accounts
@@ -29,6 +81,12 @@ SET balance = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: AddAccountBalance :one
 -- sqlc.arg(parameterName) // use the parameter name instead of default generated param name by sqlc
 UPDATE accounts
@@ -36,6 +94,12 @@ SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
+-- name: AddAccountHeldAmount :one
+UPDATE accounts
+SET held_amount = held_amount + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: DeleteAccount :exec
 DELETE FROM accounts
 WHERE id = $1;
\ No newline at end of file
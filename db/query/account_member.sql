@@ -0,0 +1,43 @@
+-- name: CreateAccountMember :one
+INSERT INTO account_members (
+    account_id,
+    username,
+    role,
+    spend_limit,
+    status,
+    invited_by,
+    accepted_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetAccountMember :one
+SELECT * FROM account_members
+WHERE account_id = $1 AND username = $2
+LIMIT 1;
+
+-- name: ListAccountMembers :many
+SELECT * FROM account_members
+WHERE account_id = $1
+ORDER BY id;
+
+-- name: ListAccountInvitations :many
+-- The pending invitations sent to the user
+SELECT * FROM account_members
+WHERE username = $1 AND status = 'invited'
+ORDER BY id;
+
+-- name: AcceptAccountMember :one
+UPDATE account_members
+SET
+    status = 'active',
+    accepted_at = now()
+WHERE
+    account_id = $1
+    AND username = $2
+    AND status = 'invited'
+RETURNING *;
+
+-- name: DeleteAccountMember :exec
+DELETE FROM account_members
+WHERE account_id = $1 AND username = $2;
@@ -1,9 +1,11 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
     account_id,
-    amount
+    amount,
+    transfer_id,
+    balance_after
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -11,8 +13,75 @@ SELECT * FROM entries
 WHERE id = $1 LIMIT 1;
 
 -- name: ListEntries :many
+-- after_id is the keyset cursor, offset is only used by the deprecated page_id pagination
 SELECT * FROM entries
-WHERE account_id = $1
+WHERE
+  account_id = sqlc.arg(account_id) AND
+  id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
\ No newline at end of file
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: GetEntriesTotalSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total FROM entries
+WHERE account_id = $1 AND created_at >= $2;
+
+-- name: GetOpeningBalance :one
+-- The balance of the account right before from_time, read from the balance after the entries around it
+-- It is only the current balance when the account has no entry since from_time
+SELECT COALESCE(
+    (
+        SELECT balance_after FROM entries
+        WHERE account_id = sqlc.arg(account_id)::bigint AND created_at < sqlc.arg(from_time)::timestamptz
+        ORDER BY id DESC
+        LIMIT 1
+    ),
+    (
+        SELECT balance_after - amount FROM entries
+        WHERE account_id = sqlc.arg(account_id)::bigint AND created_at >= sqlc.arg(from_time)::timestamptz
+        ORDER BY id
+        LIMIT 1
+    ),
+    (SELECT balance FROM accounts WHERE id = sqlc.arg(account_id)::bigint)
+)::bigint AS opening_balance;
+
+-- name: ListStatementEntries :many
+-- The counterpart is the other account of the transfer, if the entry belongs to one
+-- The account of the recipient of a payment is only listed for its own entries, the other side gets the username or email it paid
+SELECT
+    entries.id,
+    entries.amount,
+    entries.created_at,
+    entries.transfer_id,
+    transfers.reversal_of_transfer_id,
+    CASE WHEN transfers.recipient IS NULL OR entries.account_id = (
+        CASE WHEN transfers.reversal_of_transfer_id IS NULL
+        THEN transfers.to_account_id
+        ELSE transfers.from_account_id
+        END
+    )
+    THEN counterpart.id
+    END AS counterpart_account_id,
+    CASE WHEN transfers.recipient IS NULL OR entries.account_id = (
+        CASE WHEN transfers.reversal_of_transfer_id IS NULL
+        THEN transfers.to_account_id
+        ELSE transfers.from_account_id
+        END
+    )
+    THEN counterpart.owner
+    ELSE transfers.recipient
+    END AS counterpart_owner,
+    counterpart.currency AS counterpart_currency
+FROM entries
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+LEFT JOIN accounts AS counterpart ON counterpart.id = (
+    CASE WHEN transfers.from_account_id = entries.account_id
+    THEN transfers.to_account_id
+    ELSE transfers.from_account_id
+    END
+)
+WHERE
+    entries.account_id = sqlc.arg(account_id) AND
+    entries.created_at >= sqlc.arg(from_time) AND
+    entries.created_at < sqlc.arg(to_time)
+ORDER BY entries.id;
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- name: CreateFeeCharge :one
+INSERT INTO fee_charges (
+    account_id,
+    kind,
+    amount,
+    period,
+    transfer_id,
+    charged_transfer_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetPeriodicFeeCharge :one
+SELECT * FROM fee_charges
+WHERE
+    account_id = $1 AND
+    kind = $2 AND
+    period = $3
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- name: GetFeeSchedule :one
+SELECT * FROM fee_schedules
+WHERE product = $1 AND currency = $2 LIMIT 1;
+
+-- name: ListFeeSchedules :many
+SELECT * FROM fee_schedules
+ORDER BY product, currency;
+
+-- name: UpsertFeeSchedule :one
+INSERT INTO fee_schedules (
+    product,
+    currency,
+    monthly_fee,
+    transfer_fee,
+    minimum_balance,
+    below_minimum_fee,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (product, currency) DO UPDATE
+SET
+    monthly_fee = EXCLUDED.monthly_fee,
+    transfer_fee = EXCLUDED.transfer_fee,
+    minimum_balance = EXCLUDED.minimum_balance,
+    below_minimum_fee = EXCLUDED.below_minimum_fee,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
\ No newline at end of file
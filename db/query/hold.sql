@@ -0,0 +1,35 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+    account_id,
+    to_account_id,
+    amount,
+    expires_at,
+    memo,
+    external_reference,
+    metadata,
+    recipient
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListExpiredHolds :many
+SELECT * FROM holds
+WHERE
+    status = 'pending' AND
+    expires_at <= now()
+ORDER BY id
+LIMIT $1;
+
+-- name: UpdateHoldStatus :one
+UPDATE holds
+SET
+    status = $2,
+    transfer_id = $3,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,27 @@
+-- name: CreateIdempotencyKey :one
+-- Returns no row when the key is already taken and has not expired yet
+INSERT INTO idempotency_keys (
+    username,
+    key,
+    request_hash,
+    expires_at
+) VALUES (
+    @username, @key, @request_hash, now() + @ttl::interval
+)
+ON CONFLICT (username, key) DO UPDATE
+SET
+    request_hash = EXCLUDED.request_hash,
+    response = NULL,
+    created_at = now(),
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= now()
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE username = $1 AND key = $2 LIMIT 1;
+
+-- name: UpdateIdempotencyKeyResponse :exec
+UPDATE idempotency_keys
+SET response = $3
+WHERE username = $1 AND key = $2;
\ No newline at end of file
@@ -0,0 +1,35 @@
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+  account_id,
+  accrual_date,
+  balance,
+  annual_rate_bps,
+  amount,
+  remainder
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetLastInterestAccrual :one
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT 1;
+
+-- name: ListUnpostedInterestAccruals :many
+-- The rows are locked, so concurrent postings can not pay the same accruals twice
+SELECT * FROM interest_accruals
+WHERE
+  account_id = sqlc.arg(account_id) AND
+  transfer_id IS NULL AND
+  accrual_date <= sqlc.arg(through)
+ORDER BY accrual_date
+FOR UPDATE;
+
+-- name: PostInterestAccruals :exec
+UPDATE interest_accruals
+SET transfer_id = sqlc.arg(transfer_id)
+WHERE
+  account_id = sqlc.arg(account_id) AND
+  transfer_id IS NULL AND
+  accrual_date <= sqlc.arg(through);
\ No newline at end of file
@@ -0,0 +1,61 @@
+-- name: CreatePayee :one
+INSERT INTO payees (
+    owner,
+    nickname,
+    to_account_id,
+    to_username,
+    currency,
+    status
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetPayee :one
+SELECT * FROM payees
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPayees :many
+SELECT * FROM payees
+WHERE
+    owner = sqlc.arg(owner) AND
+    id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: UpdatePayee :one
+UPDATE payees
+SET
+    nickname = COALESCE(sqlc.narg(nickname), nickname),
+    updated_at = now()
+WHERE
+    id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetPayeeConfirmationCode :one
+UPDATE payees
+SET
+    confirmation_code = sqlc.arg(confirmation_code),
+    confirmation_expires_at = sqlc.arg(confirmation_expires_at),
+    updated_at = now()
+WHERE
+    id = sqlc.arg(id)
+    AND status = 'pending'
+RETURNING *;
+
+-- name: ConfirmPayee :one
+UPDATE payees
+SET
+    status = 'active',
+    confirmation_code = NULL,
+    confirmation_expires_at = NULL,
+    updated_at = now()
+WHERE
+    id = sqlc.arg(id)
+    AND status = 'pending'
+    AND confirmation_code = sqlc.arg(confirmation_code)
+    AND confirmation_expires_at > now()
+RETURNING *;
+
+-- name: DeletePayee :exec
+DELETE FROM payees
+WHERE id = $1;
@@ -0,0 +1,35 @@
+-- name: CreateReconciliationReport :one
+INSERT INTO reconciliation_reports (
+    account_id,
+    balance,
+    entries_total,
+    difference
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListAccountDiscrepancies :many
+-- Accounts whose difference between the balance and the sum of their entries changed since their last report
+-- An account never reported counts as balanced, so a known discrepancy is only alerted once
+-- and a resolved one is returned with a zero difference, after which the same drift is alerted again
+SELECT
+    accounts.id AS account_id,
+    accounts.balance,
+    COALESCE(SUM(entries.amount), 0)::bigint AS entries_total
+FROM accounts
+LEFT JOIN entries ON entries.account_id = accounts.id
+GROUP BY accounts.id
+HAVING
+    accounts.balance - COALESCE(SUM(entries.amount), 0) <> COALESCE((
+        SELECT difference FROM reconciliation_reports
+        WHERE reconciliation_reports.account_id = accounts.id
+        ORDER BY reconciliation_reports.id DESC
+        LIMIT 1
+    ), 0)
+ORDER BY accounts.id;
+
+-- name: ListReconciliationReports :many
+SELECT * FROM reconciliation_reports
+WHERE id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- name: CreateRiskDecision :one
+INSERT INTO risk_decisions (
+    username,
+    from_account_id,
+    to_account_id,
+    amount,
+    decision,
+    rules,
+    transfer_id,
+    hold_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetRiskDecisionForUpdate :one
+SELECT * FROM risk_decisions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListRiskDecisions :many
+-- pending_review keeps only the transfers held until a banker resolves them
+SELECT * FROM risk_decisions
+WHERE
+    (NOT sqlc.arg(pending_review)::boolean OR (decision = 'review' AND resolution IS NULL)) AND
+    id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: ResolveRiskDecision :one
+UPDATE risk_decisions
+SET
+    resolution = $2,
+    reviewed_by = $3,
+    reviewed_at = now(),
+    transfer_id = $4
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,43 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    recurrence,
+    next_run_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE
+    owner = sqlc.arg(owner) AND
+    id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: ListDueScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE
+    status = 'active' AND
+    next_run_at <= now()
+ORDER BY next_run_at
+LIMIT $1;
+
+-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+SET
+    amount = COALESCE(sqlc.narg(amount), amount),
+    recurrence = COALESCE(sqlc.narg(recurrence), recurrence),
+    status = COALESCE(sqlc.narg(status), status),
+    next_run_at = CASE WHEN sqlc.arg(clear_next_run_at)::boolean THEN NULL ELSE COALESCE(sqlc.narg(next_run_at), next_run_at) END,
+    updated_at = now()
+WHERE
+    id = sqlc.arg(id)
+RETURNING *;
\ No newline at end of file
@@ -2,20 +2,63 @@
 INSERT INTO transfers (
 	from_account_id,
 	to_account_id,
-	amount
+	amount,
+	to_amount,
+	exchange_rate,
+	quote_id,
+	reason_code,
+	external_reference,
+	reversal_of_transfer_id,
+	memo,
+	metadata,
+	recipient
 ) VALUES (
-	$1, $2, $3
+	$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 ) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetTransferReversedAmount :one
+-- amount is debited from the original to account, to_amount is refunded to the original from account
+SELECT
+	COALESCE(SUM(amount), 0)::bigint AS amount,
+	COALESCE(SUM(to_amount), 0)::bigint AS to_amount
+FROM transfers
+WHERE reversal_of_transfer_id = $1;
+
+-- name: CountTransfersBetween :one
+SELECT COUNT(*) FROM transfers
+WHERE
+	from_account_id = $1 AND
+	to_account_id = $2;
+
+-- name: GetDailyTransferTotal :one
+-- Only the transfers sent by customers count: deposits and withdrawals have a reason code and reversals are bank corrections
+SELECT
+	COALESCE(SUM(amount), 0)::bigint AS amount,
+	COUNT(*) AS count
+FROM transfers
+WHERE
+	from_account_id = sqlc.arg(from_account_id) AND
+	created_at >= sqlc.arg(since) AND
+	reason_code IS NULL AND
+	reversal_of_transfer_id IS NULL;
+
 -- name: ListTransfers :many
+-- after_id is the keyset cursor, offset is only used by the deprecated page_id pagination
+-- external_reference only keeps the transfers with this reference when it is set
 SELECT * FROM transfers
 WHERE
-	from_account_id = $1 OR
-	to_account_id = $2
+	(from_account_id = sqlc.arg(from_account_id) OR to_account_id = sqlc.arg(to_account_id)) AND
+	id > sqlc.arg(after_id) AND
+	(sqlc.narg(external_reference)::varchar IS NULL OR external_reference = sqlc.narg(external_reference))
 ORDER BY id
-LIMIT $3
-OFFSET $4;
\ No newline at end of file
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
\ No newline at end of file
@@ -0,0 +1,33 @@
+-- name: CompleteTransferBatch :one
+-- Summarizes the rows, which must all be processed
+UPDATE transfer_batches
+SET
+    status = 'completed',
+    succeeded_count = summary.succeeded_count,
+    failed_count = summary.failed_count,
+    succeeded_amount = summary.succeeded_amount,
+    completed_at = now()
+FROM (
+    SELECT
+        COUNT(*) FILTER (WHERE status = 'succeeded')::integer AS succeeded_count,
+        COUNT(*) FILTER (WHERE status = 'failed')::integer AS failed_count,
+        COALESCE(SUM(amount) FILTER (WHERE status = 'succeeded'), 0)::bigint AS succeeded_amount
+    FROM transfer_batch_rows
+    WHERE batch_id = sqlc.arg(id)
+) AS summary
+WHERE transfer_batches.id = sqlc.arg(id)
+RETURNING transfer_batches.*;
+
+-- name: CreateTransferBatch :one
+INSERT INTO transfer_batches (
+    owner,
+    from_account_id,
+    row_count,
+    total_amount
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetTransferBatch :one
+SELECT * FROM transfer_batches
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
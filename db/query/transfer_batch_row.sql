@@ -0,0 +1,31 @@
+-- name: CreateTransferBatchRow :one
+INSERT INTO transfer_batch_rows (
+    batch_id,
+    row_number,
+    to_account_id,
+    amount,
+    reference
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: FinishTransferBatchRow :one
+-- Returns no row when the row was already processed
+UPDATE transfer_batch_rows
+SET
+    status = sqlc.arg(status),
+    transfer_id = sqlc.arg(transfer_id),
+    error = sqlc.arg(error),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: GetTransferBatchRowForUpdate :one
+SELECT * FROM transfer_batch_rows
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListTransferBatchRows :many
+SELECT * FROM transfer_batch_rows
+WHERE batch_id = $1
+ORDER BY row_number;
\ No newline at end of file
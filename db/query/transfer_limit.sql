@@ -0,0 +1,7 @@
+-- name: GetTransferLimit :one
+SELECT * FROM transfer_limits
+WHERE
+    role = $1 AND
+    tier = $2 AND
+    currency = $3
+LIMIT 1;
\ No newline at end of file
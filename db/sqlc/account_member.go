@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Roles of an account member
+// The owner of the account is a member from the start, the other roles are invited by an owner or a co-owner
+const (
+	AccountMemberRoleOwner   = "owner"
+	AccountMemberRoleCoOwner = "co_owner"
+	AccountMemberRoleViewer  = "viewer"
+	AccountMemberRoleSpender = "spender"
+)
+
+// Statuses of an account member
+// An invited member has no access to the account until the invitation is accepted
+const (
+	AccountMemberStatusInvited = "invited"
+	AccountMemberStatusActive  = "active"
+)
+
+// Permissions on an account, checked by AuthorizeAccount
+const (
+	AccountPermissionView          = "view"
+	AccountPermissionSpend         = "spend"
+	AccountPermissionManageMembers = "manage_members"
+	AccountPermissionClose         = "close"
+)
+
+// accountMemberPermissions lists the permissions of each role
+var accountMemberPermissions = map[string][]string{
+	AccountMemberRoleOwner:   {AccountPermissionView, AccountPermissionSpend, AccountPermissionManageMembers, AccountPermissionClose},
+	AccountMemberRoleCoOwner: {AccountPermissionView, AccountPermissionSpend, AccountPermissionManageMembers},
+	AccountMemberRoleSpender: {AccountPermissionView, AccountPermissionSpend},
+	AccountMemberRoleViewer:  {AccountPermissionView},
+}
+
+// IsAccountMemberRole reports whether the role exists
+func IsAccountMemberRole(role string) bool {
+	_, ok := accountMemberPermissions[role]
+	return ok
+}
+
+// hasAccountPermission reports whether the role grants the permission
+func hasAccountPermission(role string, permission string) bool {
+	for _, granted := range accountMemberPermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuthorizeAccountParams contains the input parameters of AuthorizeAccount
+type AuthorizeAccountParams struct {
+	Account    Account
+	Username   string
+	Permission string
+	// Amount moved out of the account, only checked against the limit of a spender
+	Amount int64
+}
+
+// AuthorizeAccount makes sure the user is an active member of the account whose role grants the permission.
+// The owner recorded on the account is allowed everything without loading its membership.
+func AuthorizeAccount(ctx context.Context, q Querier, arg AuthorizeAccountParams) error {
+	if arg.Account.Owner == arg.Username {
+		return nil
+	}
+
+	member, err := q.GetAccountMember(ctx, GetAccountMemberParams{
+		AccountID: arg.Account.ID,
+		Username:  arg.Username,
+	})
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return ErrAccountAccessDenied
+		}
+		return err
+	}
+
+	if member.Status != AccountMemberStatusActive || !hasAccountPermission(member.Role, arg.Permission) {
+		return ErrAccountAccessDenied
+	}
+
+	if arg.Permission == AccountPermissionSpend && member.SpendLimit.Valid && arg.Amount > member.SpendLimit.Int64 {
+		return fmt.Errorf("%w: at most %d %s (minor units) per transfer", ErrSpendLimitExceeded, member.SpendLimit.Int64, arg.Account.Currency)
+	}
+
+	return nil
+}
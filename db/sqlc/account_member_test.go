@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAccountMember(t *testing.T, account Account, role string, spendLimit pgtype.Int8) AccountMember {
+	user := createRandomUser(t)
+
+	member, err := testStore.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+		AccountID:  account.ID,
+		Username:   user.Username,
+		Role:       role,
+		SpendLimit: spendLimit,
+		Status:     AccountMemberStatusInvited,
+		InvitedBy:  account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountMemberStatusInvited, member.Status)
+	require.False(t, member.AcceptedAt.Valid)
+
+	return member
+}
+
+func TestAccountMemberInvitation(t *testing.T) {
+	account := createRandomAccount(t)
+	member := createRandomAccountMember(t, account, AccountMemberRoleViewer, pgtype.Int8{})
+
+	invitations, err := testStore.ListAccountInvitations(context.Background(), member.Username)
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	require.Equal(t, member, invitations[0])
+
+	// Shared accounts are only listed once the invitation is accepted
+	accounts, err := testStore.ListAccounts(context.Background(), ListAccountsParams{
+		Owner: member.Username,
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+
+	accepted, err := testStore.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountMemberStatusActive, accepted.Status)
+	require.True(t, accepted.AcceptedAt.Valid)
+
+	_, err = testStore.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	accounts, err = testStore.ListAccounts(context.Background(), ListAccountsParams{
+		Owner: member.Username,
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	members, err := testStore.ListAccountMembers(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+
+	err = testStore.DeleteAccountMember(context.Background(), DeleteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.GetAccountMember(context.Background(), GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestAuthorizeAccount(t *testing.T) {
+	account := createRandomAccount(t)
+	spendLimit := pgtype.Int8{
+		Int64: 100,
+		Valid: true,
+	}
+	spender := createRandomAccountMember(t, account, AccountMemberRoleSpender, spendLimit)
+	viewer := createRandomAccountMember(t, account, AccountMemberRoleViewer, pgtype.Int8{})
+	stranger := createRandomUser(t)
+
+	authorize := func(username string, permission string, amount int64) error {
+		return AuthorizeAccount(context.Background(), testStore, AuthorizeAccountParams{
+			Account:    account,
+			Username:   username,
+			Permission: permission,
+			Amount:     amount,
+		})
+	}
+
+	require.NoError(t, authorize(account.Owner, AccountPermissionClose, 0))
+	require.ErrorIs(t, authorize(stranger.Username, AccountPermissionView, 0), ErrAccountAccessDenied)
+
+	// An invited member has no access yet
+	require.ErrorIs(t, authorize(spender.Username, AccountPermissionView, 0), ErrAccountAccessDenied)
+
+	for _, member := range []AccountMember{spender, viewer} {
+		_, err := testStore.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+			AccountID: account.ID,
+			Username:  member.Username,
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, authorize(spender.Username, AccountPermissionSpend, spendLimit.Int64))
+	require.ErrorIs(t, authorize(spender.Username, AccountPermissionSpend, spendLimit.Int64+1), ErrSpendLimitExceeded)
+	require.ErrorIs(t, authorize(spender.Username, AccountPermissionManageMembers, 0), ErrAccountAccessDenied)
+	require.NoError(t, authorize(viewer.Username, AccountPermissionView, 0))
+	require.ErrorIs(t, authorize(viewer.Username, AccountPermissionSpend, 1), ErrAccountAccessDenied)
+}
@@ -0,0 +1,26 @@
+package db
+
+// Statuses of an account
+// An account is frozen and unfrozen by bankers, closed accounts can not be reopened
+const (
+	AccountStatusActive = "active"
+	AccountStatusFrozen = "frozen"
+	AccountStatusClosed = "closed"
+)
+
+// accountStatusChanges lists the statuses each status can change to
+var accountStatusChanges = map[string][]string{
+	AccountStatusActive: {AccountStatusFrozen, AccountStatusClosed},
+	AccountStatusFrozen: {AccountStatusActive},
+}
+
+// canChangeStatus reports whether an account can go from one status to the other
+func canChangeStatus(from string, to string) bool {
+	for _, status := range accountStatusChanges[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomCurrency(t *testing.T) Currency {
+	arg := CreateCurrencyParams{
+		Code:        strings.ToUpper(util.RandomString(6)),
+		NumericCode: int32(util.RandomInt(1000, 1_000_000)),
+		Exponent:    int32(util.RandomInt(0, 3)),
+		Symbol:      util.RandomString(2),
+		Enabled:     true,
+	}
+
+	currency, err := testStore.CreateCurrency(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Code, currency.Code)
+	require.Equal(t, arg.NumericCode, currency.NumericCode)
+	require.Equal(t, arg.Exponent, currency.Exponent)
+	require.Equal(t, arg.Symbol, currency.Symbol)
+	require.Equal(t, arg.Enabled, currency.Enabled)
+	require.NotZero(t, currency.CreatedAt)
+
+	return currency
+}
+
+func TestCreateCurrency(t *testing.T) {
+	createRandomCurrency(t)
+}
+
+func TestGetSeededCurrency(t *testing.T) {
+	currency, err := testStore.GetCurrency(context.Background(), util.JPY)
+	require.NoError(t, err)
+	require.Equal(t, int32(392), currency.NumericCode)
+	require.Zero(t, currency.Exponent)
+}
+
+func TestUpdateCurrency(t *testing.T) {
+	oldCurrency := createRandomCurrency(t)
+
+	newCurrency, err := testStore.UpdateCurrency(context.Background(), UpdateCurrencyParams{
+		Code: oldCurrency.Code,
+		Enabled: pgtype.Bool{
+			Bool:  false,
+			Valid: true,
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, newCurrency.Enabled)
+	require.Equal(t, oldCurrency.Symbol, newCurrency.Symbol)
+	require.Equal(t, oldCurrency.Exponent, newCurrency.Exponent)
+}
+
+func TestListCurrencies(t *testing.T) {
+	createRandomCurrency(t)
+
+	currencies, err := testStore.ListCurrencies(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(currencies), 5)
+}
@@ -7,36 +7,67 @@ package db
 
 import (
 	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
     account_id,
-    amount
+    amount,
+    transfer_id,
+    balance_after
 ) VALUES (
-    $1, $2
-) RETURNING id, account_id, amount, created_at
+    $1, $2, $3, $4
+) RETURNING id, account_id, amount, created_at, transfer_id, balance_after
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID    int64       `json:"account_id"`
+	Amount       int64       `json:"amount"`
+	TransferID   pgtype.Int8 `json:"transfer_id"`
+	BalanceAfter int64       `json:"balance_after"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.TransferID,
+		arg.BalanceAfter,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.BalanceAfter,
 	)
 	return i, err
 }
 
+const getEntriesTotalSince = `-- name: GetEntriesTotalSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total FROM entries
+WHERE account_id = $1 AND created_at >= $2
+`
+
+type GetEntriesTotalSinceParams struct {
+	AccountID int64     `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) GetEntriesTotalSince(ctx context.Context, arg GetEntriesTotalSinceParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getEntriesTotalSince, arg.AccountID, arg.CreatedAt)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id, balance_after FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -48,26 +79,69 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.BalanceAfter,
 	)
 	return i, err
 }
 
+const getOpeningBalance = `-- name: GetOpeningBalance :one
+SELECT COALESCE(
+    (
+        SELECT balance_after FROM entries
+        WHERE account_id = $1::bigint AND created_at < $2::timestamptz
+        ORDER BY id DESC
+        LIMIT 1
+    ),
+    (
+        SELECT balance_after - amount FROM entries
+        WHERE account_id = $1::bigint AND created_at >= $2::timestamptz
+        ORDER BY id
+        LIMIT 1
+    ),
+    (SELECT balance FROM accounts WHERE id = $1::bigint)
+)::bigint AS opening_balance
+`
+
+type GetOpeningBalanceParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+}
+
+// The balance of the account right before from_time, read from the balance after the entries around it
+// It is only the current balance when the account has no entry since from_time
+func (q *Queries) GetOpeningBalance(ctx context.Context, arg GetOpeningBalanceParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getOpeningBalance, arg.AccountID, arg.FromTime)
+	var opening_balance int64
+	err := row.Scan(&opening_balance)
+	return opening_balance, err
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
-WHERE account_id = $1
+SELECT id, account_id, amount, created_at, transfer_id, balance_after FROM entries
+WHERE
+  account_id = $1 AND
+  id > $2
 ORDER BY id
-LIMIT $2
-OFFSET $3
+LIMIT $3
+OFFSET $4
 `
 
 type ListEntriesParams struct {
 	AccountID int64 `json:"account_id"`
+	AfterID   int64 `json:"after_id"`
 	Limit     int32 `json:"limit"`
 	Offset    int32 `json:"offset"`
 }
 
+// after_id is the keyset cursor, offset is only used by the deprecated page_id pagination
 func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
-	rows, err := q.db.Query(ctx, listEntries, arg.AccountID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listEntries,
+		arg.AccountID,
+		arg.AfterID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +154,96 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
+			&i.BalanceAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+SELECT
+    entries.id,
+    entries.amount,
+    entries.created_at,
+    entries.transfer_id,
+    transfers.reversal_of_transfer_id,
+    CASE WHEN transfers.recipient IS NULL OR entries.account_id = (
+        CASE WHEN transfers.reversal_of_transfer_id IS NULL
+        THEN transfers.to_account_id
+        ELSE transfers.from_account_id
+        END
+    )
+    THEN counterpart.id
+    END AS counterpart_account_id,
+    CASE WHEN transfers.recipient IS NULL OR entries.account_id = (
+        CASE WHEN transfers.reversal_of_transfer_id IS NULL
+        THEN transfers.to_account_id
+        ELSE transfers.from_account_id
+        END
+    )
+    THEN counterpart.owner
+    ELSE transfers.recipient
+    END AS counterpart_owner,
+    counterpart.currency AS counterpart_currency
+FROM entries
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+LEFT JOIN accounts AS counterpart ON counterpart.id = (
+    CASE WHEN transfers.from_account_id = entries.account_id
+    THEN transfers.to_account_id
+    ELSE transfers.from_account_id
+    END
+)
+WHERE
+    entries.account_id = $1 AND
+    entries.created_at >= $2 AND
+    entries.created_at < $3
+ORDER BY entries.id
+`
+
+type ListStatementEntriesParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+type ListStatementEntriesRow struct {
+	ID                   int64       `json:"id"`
+	Amount               int64       `json:"amount"`
+	CreatedAt            time.Time   `json:"created_at"`
+	TransferID           pgtype.Int8 `json:"transfer_id"`
+	ReversalOfTransferID pgtype.Int8 `json:"reversal_of_transfer_id"`
+	CounterpartAccountID pgtype.Int8 `json:"counterpart_account_id"`
+	CounterpartOwner     pgtype.Text `json:"counterpart_owner"`
+	CounterpartCurrency  pgtype.Text `json:"counterpart_currency"`
+}
+
+// The counterpart is the other account of the transfer, if the entry belongs to one
+// The account of the recipient of a payment is only listed for its own entries, the other side gets the username or email it paid
+func (q *Queries) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listStatementEntries, arg.AccountID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatementEntriesRow{}
+	for rows.Next() {
+		var i ListStatementEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.ReversalOfTransferID,
+			&i.CounterpartAccountID,
+			&i.CounterpartOwner,
+			&i.CounterpartCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -11,8 +11,9 @@ import (
 
 func createRandomEntry(t *testing.T, account Account) Entry {
 	arg := CreateEntryParams{
-		AccountID: account.ID,
-		Amount:    util.RandomMoney(),
+		AccountID:    account.ID,
+		Amount:       util.RandomMoney(),
+		BalanceAfter: util.RandomMoney(),
 	}
 
 	entry, err := testStore.CreateEntry(context.Background(), arg)
@@ -21,6 +22,7 @@ func createRandomEntry(t *testing.T, account Account) Entry {
 
 	require.Equal(t, arg.AccountID, entry.AccountID)
 	require.Equal(t, arg.Amount, entry.Amount)
+	require.Equal(t, arg.BalanceAfter, entry.BalanceAfter)
 
 	require.NotZero(t, entry.ID)
 	require.NotZero(t, entry.CreatedAt)
@@ -43,6 +45,7 @@ func TestGetEntry(t *testing.T) {
 	require.Equal(t, entry1.ID, entry1.ID)
 	require.Equal(t, entry1.AccountID, entry2.AccountID)
 	require.Equal(t, entry1.Amount, entry2.Amount)
+	require.Equal(t, entry1.BalanceAfter, entry2.BalanceAfter)
 	require.WithinDuration(t, entry1.CreatedAt, entry2.CreatedAt, time.Second)
 }
 
@@ -67,3 +70,33 @@ func TestListEntries(t *testing.T) {
 		require.Equal(t, account.ID, entry.AccountID)
 	}
 }
+
+func TestListEntriesAfterID(t *testing.T) {
+	account := createRandomAccount(t)
+	for index := 0; index < 10; index++ {
+		createRandomEntry(t, account)
+	}
+
+	firstPage, err := testStore.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account.ID,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, firstPage, 5)
+
+	// A new entry must not shift the next page
+	createRandomEntry(t, account)
+
+	secondPage, err := testStore.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account.ID,
+		AfterID:   firstPage[4].ID,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, secondPage, 5)
+
+	for _, entry := range secondPage {
+		require.Greater(t, entry.ID, firstPage[4].ID)
+		require.Equal(t, account.ID, entry.AccountID)
+	}
+}
@@ -17,6 +17,20 @@ var (
 	ErrUniqueViolation = &pgconn.PgError{
 		Code: UniqueViolation,
 	}
+	ErrIdempotencyKeyConflict     = errors.New("idempotency key was already used with a different request")
+	ErrInsufficientFunds          = errors.New("insufficient funds")
+	ErrAccountNotActive           = errors.New("account is not active")
+	ErrInvalidStatusChange        = errors.New("account status can not be changed this way")
+	ErrNonZeroBalance             = errors.New("account balance must be zero")
+	ErrHoldNotPending             = errors.New("hold was already captured, released or expired")
+	ErrScheduledTransferCancelled = errors.New("scheduled transfer was cancelled")
+	ErrReversalOfReversal         = errors.New("a reversal can not be reversed")
+	ErrInvalidReversalAmount      = errors.New("reversal amount must be positive and not exceed what is left to reverse")
+	ErrTransferLimitExceeded      = errors.New("transfer limit exceeded")
+	ErrRiskDecisionNotPending     = errors.New("risk decision is not waiting for a review")
+	ErrTransferBatchRowProcessed  = errors.New("transfer batch row was already processed")
+	ErrAccountAccessDenied        = errors.New("the account doesn't belong to the authenticated user")
+	ErrSpendLimitExceeded         = errors.New("amount exceeds the spend limit of the account member")
 )
 
 func ErrorCode(err error) string {
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Kinds of fee, the amounts of each kind are stored in the fee_schedules table per product and currency
+const (
+	FeeKindMonthly      = "monthly"
+	FeeKindTransfer     = "transfer"
+	FeeKindBelowMinimum = "below_minimum"
+)
+
+// FeeLine is a fee charged to an account, it is posted as its own transfer to the fee revenue account
+// Transfer and Entry are empty when nothing could be charged
+type FeeLine struct {
+	Charge   FeeCharge `json:"charge"`
+	Transfer Transfer  `json:"transfer"`
+	Entry    Entry     `json:"entry"`
+}
+
+// chargeFee moves charge.Amount from the account to the fee revenue account of its currency and records the charge
+// The account must be locked by the caller, it is returned with its balance after the fee
+func chargeFee(ctx context.Context, q *Queries, account Account, charge CreateFeeChargeParams) (FeeLine, Account, error) {
+	var line FeeLine
+
+	if charge.Amount > 0 {
+		fees, err := getSystemAccount(ctx, q, SystemFeesOwner, account.Currency)
+		if err != nil {
+			return line, account, err
+		}
+
+		posted, err := postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: account.ID,
+			ToAccountID:   fees.ID,
+			Amount:        charge.Amount,
+			ToAmount:      charge.Amount,
+			ReasonCode: pgtype.Text{
+				String: util.FeeReason,
+				Valid:  true,
+			},
+		}, true)
+		if err != nil {
+			return line, account, err
+		}
+
+		line.Transfer = posted.Transfer
+		line.Entry = posted.FromEntry
+		account = posted.FromAccount
+		charge.TransferID = pgtype.Int8{
+			Int64: posted.Transfer.ID,
+			Valid: true,
+		}
+	}
+
+	var err error
+	line.Charge, err = q.CreateFeeCharge(ctx, charge)
+	return line, account, err
+}
+
+// chargeTransferFee charges the transfer fee of the from account product to the sender of a posted transfer
+// The sender must be able to pay both the transfer and its fee
+func chargeTransferFee(ctx context.Context, q *Queries, result *TransferTxResult) error {
+	schedule, err := q.GetFeeSchedule(ctx, GetFeeScheduleParams{
+		Product:  result.FromAccount.Product,
+		Currency: result.FromAccount.Currency,
+	})
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if schedule.TransferFee <= 0 {
+		return nil
+	}
+
+	line, account, err := chargeFee(ctx, q, result.FromAccount, CreateFeeChargeParams{
+		AccountID: result.FromAccount.ID,
+		Kind:      FeeKindTransfer,
+		Amount:    schedule.TransferFee,
+		ChargedTransferID: pgtype.Int8{
+			Int64: result.Transfer.ID,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	result.FromAccount = account
+	result.Fees = append(result.Fees, line)
+	return nil
+}
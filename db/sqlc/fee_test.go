@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createFeeSchedule sets the fees of the checking product in a new currency, so no other test is charged them
+func createFeeSchedule(t *testing.T, arg UpsertFeeScheduleParams) FeeSchedule {
+	arg.Product = AccountProductChecking
+	arg.Currency = createRandomCurrency(t).Code
+	arg.UpdatedBy = createRandomUser(t).Username
+
+	schedule, err := testStore.UpsertFeeSchedule(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.TransferFee, schedule.TransferFee)
+	require.NotZero(t, schedule.UpdatedAt)
+
+	return schedule
+}
+
+// createScheduleAccount creates an account charged the fees of the schedule, holding exactly the given balance
+func createScheduleAccount(t *testing.T, schedule FeeSchedule, balance int64) Account {
+	account, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Balance:  balance,
+		Currency: schedule.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, schedule.Product, account.Product)
+
+	return account
+}
+
+func TestTransferTxFee(t *testing.T) {
+	schedule := createFeeSchedule(t, UpsertFeeScheduleParams{
+		TransferFee: 25,
+	})
+	account1 := createScheduleAccount(t, schedule, 1_000)
+	account2 := createScheduleAccount(t, schedule, 0)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(875), result.FromAccount.Balance)
+	require.Equal(t, int64(100), result.ToAccount.Balance)
+	require.Equal(t, int64(-100), result.FromEntry.Amount)
+	require.Len(t, result.Fees, 1)
+
+	// The fee is a separate transfer to the fee revenue account
+	fee := result.Fees[0]
+	require.Equal(t, FeeKindTransfer, fee.Charge.Kind)
+	require.Equal(t, int64(25), fee.Charge.Amount)
+	require.Equal(t, result.Transfer.ID, fee.Charge.ChargedTransferID.Int64)
+	require.Equal(t, fee.Transfer.ID, fee.Charge.TransferID.Int64)
+	require.Equal(t, util.FeeReason, fee.Transfer.ReasonCode.String)
+	require.Equal(t, int64(-25), fee.Entry.Amount)
+	require.Equal(t, int64(875), fee.Entry.BalanceAfter)
+
+	fees, err := testStore.GetAccount(context.Background(), fee.Transfer.ToAccountID)
+	require.NoError(t, err)
+	require.Equal(t, SystemFeesOwner, fees.Owner)
+	require.Equal(t, schedule.Currency, fees.Currency)
+}
+
+func TestTransferTxFeeInsufficientFunds(t *testing.T) {
+	schedule := createFeeSchedule(t, UpsertFeeScheduleParams{
+		TransferFee: 25,
+	})
+	account1 := createScheduleAccount(t, schedule, 100)
+	account2 := createScheduleAccount(t, schedule, 0)
+
+	// The sender must afford both the transfer and its fee
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	account1, err = testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account1.Balance)
+}
+
+func TestCaptureHoldFee(t *testing.T) {
+	schedule := createFeeSchedule(t, UpsertFeeScheduleParams{
+		TransferFee: 25,
+	})
+	account1 := createScheduleAccount(t, schedule, 1_000)
+	account2 := createScheduleAccount(t, schedule, 0)
+	hold := createRandomHold(t, account1, account2, 100, time.Now().Add(time.Hour))
+
+	result, err := testStore.CaptureHold(context.Background(), CaptureHoldParams{
+		HoldID: hold.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(875), result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(100), result.Transfer.ToAccount.Balance)
+	require.Len(t, result.Transfer.Fees, 1)
+
+	fee := result.Transfer.Fees[0]
+	require.Equal(t, FeeKindTransfer, fee.Charge.Kind)
+	require.Equal(t, int64(25), fee.Charge.Amount)
+	require.Equal(t, result.Transfer.Transfer.ID, fee.Charge.ChargedTransferID.Int64)
+}
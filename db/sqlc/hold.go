@@ -0,0 +1,10 @@
+package db
+
+// Statuses of a hold
+// A pending hold reserves funds until it is captured, released or expires
+const (
+	HoldStatusPending  = "pending"
+	HoldStatusCaptured = "captured"
+	HoldStatusReleased = "released"
+	HoldStatusExpired  = "expired"
+)
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// IdempotencyParams identifies a client request that must be executed at most once
+type IdempotencyParams struct {
+	Username string
+	Key      string
+	TTL      time.Duration
+}
+
+// requestHash returns a fingerprint of the request parameters
+// It is used to detect a key which is reused with a different request
+func requestHash(arg interface{}) (string, error) {
+	data, err := json.Marshal(arg)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// claimIdempotencyKey reserves the key for the current transaction
+// If the key was already used by a committed request, the stored response is decoded into result and replayed is true
+// Concurrent requests with the same key wait on the row lock until the first one commits or rolls back
+func claimIdempotencyKey(ctx context.Context, q *Queries, params *IdempotencyParams, hash string, result interface{}) (replayed bool, err error) {
+	_, err = q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Username:    params.Username,
+		Key:         params.Key,
+		RequestHash: hash,
+		Ttl: pgtype.Interval{
+			Microseconds: params.TTL.Microseconds(),
+			Valid:        true,
+		},
+	})
+	if err == nil {
+		return false, nil
+	}
+
+	if !errors.Is(err, ErrRecordNotFound) {
+		return false, err
+	}
+
+	key, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: params.Username,
+		Key:      params.Key,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if key.RequestHash != hash {
+		return false, ErrIdempotencyKeyConflict
+	}
+
+	if err = json.Unmarshal(key.Response, result); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// saveIdempotentResponse stores the response so that retries with the same key can replay it
+func saveIdempotentResponse(ctx context.Context, q *Queries, params *IdempotencyParams, result interface{}) error {
+	response, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return q.UpdateIdempotencyKeyResponse(ctx, UpdateIdempotencyKeyResponseParams{
+		Username: params.Username,
+		Key:      params.Key,
+		Response: response,
+	})
+}
+
+// IdempotentTransferResult is the stored result of a transfer request
+// The request was either transferred or, when the fraud screening asked for a review, held
+type IdempotentTransferResult struct {
+	Transfer *TransferTxResult
+	Review   *HoldTransferForReviewTxResult
+}
+
+// GetIdempotentTransfer returns the result of a transfer request already committed with the same idempotency key.
+// It is looked up before the fraud screening, which must not screen a retry again since its rules count the first attempt.
+// ErrRecordNotFound is returned when the key is free or expired, ErrIdempotencyKeyConflict when another request used it.
+func (store *SQLStore) GetIdempotentTransfer(ctx context.Context, arg TransferTxParams) (IdempotentTransferResult, error) {
+	var result IdempotentTransferResult
+
+	if arg.Idempotency == nil {
+		return result, ErrRecordNotFound
+	}
+
+	key, err := store.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: arg.Idempotency.Username,
+		Key:      arg.Idempotency.Key,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if !key.ExpiresAt.After(time.Now()) || len(key.Response) == 0 {
+		return result, ErrRecordNotFound
+	}
+
+	hash, err := requestHash(arg)
+	if err != nil {
+		return result, err
+	}
+
+	if key.RequestHash != hash {
+		return result, ErrIdempotencyKeyConflict
+	}
+
+	// Only a held transfer has a hold in its response
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(key.Response, &fields); err != nil {
+		return result, err
+	}
+
+	if _, held := fields["hold"]; held {
+		result.Review = &HoldTransferForReviewTxResult{}
+		err = json.Unmarshal(key.Response, result.Review)
+	} else {
+		result.Transfer = &TransferTxResult{}
+		err = json.Unmarshal(key.Response, result.Transfer)
+	}
+
+	return result, err
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Products of an account, the rate of each product is stored in the account_products table
+const (
+	AccountProductChecking = "checking"
+	AccountProductSavings  = "savings"
+)
+
+// interestDenominator turns an annual rate in basis points into a daily one
+// Interest follows the actual/365 fixed convention, every day earns 1/365 of the annual rate
+const interestDenominator = 10000 * 365
+
+// dailyInterest returns the interest earned by the balance in one day and the remainder carried to the next day
+// The remainder is the fraction of a minor unit which was not paid yet, in 1/interestDenominator of a minor unit,
+// so the accruals of a period add up to the exact interest of the period without any rounding drift
+func dailyInterest(balance int64, annualRateBps int32, remainder int64) (int64, int64) {
+	// Only positive balances earn interest
+	if balance <= 0 || annualRateBps <= 0 {
+		return 0, remainder
+	}
+
+	numerator := new(big.Int).Mul(big.NewInt(balance), big.NewInt(int64(annualRateBps)))
+	numerator.Add(numerator, big.NewInt(remainder))
+
+	amount, rest := new(big.Int).QuoRem(numerator, big.NewInt(interestDenominator), new(big.Int))
+	return amount.Int64(), rest.Int64()
+}
+
+// accrueInterest locks the account and records its interest for each day after its last accrual up to through
+// Days missed while the worker was down are caught up, the balance of each day is rebuilt from the entries
+func accrueInterest(ctx context.Context, q *Queries, accountID int64, through time.Time) (Account, []InterestAccrual, error) {
+	accruals := []InterestAccrual{}
+	through = through.UTC().Truncate(24 * time.Hour)
+
+	account, err := q.GetAccountForUpdate(ctx, accountID)
+	if err != nil {
+		return account, accruals, err
+	}
+
+	product, err := q.GetAccountProduct(ctx, account.Product)
+	if err != nil {
+		return account, accruals, err
+	}
+
+	day := account.CreatedAt.UTC().Truncate(24 * time.Hour)
+	var remainder int64
+
+	last, err := q.GetLastInterestAccrual(ctx, account.ID)
+	if err != nil && !errors.Is(err, ErrRecordNotFound) {
+		return account, accruals, err
+	}
+	if err == nil {
+		day = last.AccrualDate.Time.AddDate(0, 0, 1)
+		remainder = last.Remainder
+	}
+
+	for ; !day.After(through); day = day.AddDate(0, 0, 1) {
+		// Balances are only changed by entries, so the balance at the end of the day is the current one without the later entries
+		totalSince, err := q.GetEntriesTotalSince(ctx, GetEntriesTotalSinceParams{
+			AccountID: account.ID,
+			CreatedAt: day.AddDate(0, 0, 1),
+		})
+		if err != nil {
+			return account, accruals, err
+		}
+
+		balance := account.Balance - totalSince
+		amount, rest := dailyInterest(balance, product.AnnualRateBps, remainder)
+
+		accrual, err := q.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+			AccountID: account.ID,
+			AccrualDate: pgtype.Date{
+				Time:  day,
+				Valid: true,
+			},
+			Balance:       balance,
+			AnnualRateBps: product.AnnualRateBps,
+			Amount:        amount,
+			Remainder:     rest,
+		})
+		if err != nil {
+			return account, accruals, err
+		}
+
+		accruals = append(accruals, accrual)
+		remainder = rest
+	}
+
+	return account, accruals, nil
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyInterest(t *testing.T) {
+	// 2% of 10,000.00 is 54 cents a day plus a fraction which is carried
+	amount, remainder := dailyInterest(1_000_000, 200, 0)
+	require.Equal(t, int64(54), amount)
+	require.Equal(t, int64(2_900_000), remainder)
+
+	// The carried fractions add up to the exact yearly interest
+	var total int64
+	remainder = 0
+	for day := 0; day < 365; day++ {
+		amount, remainder = dailyInterest(1_000_000, 200, remainder)
+		total += amount
+	}
+	require.Equal(t, int64(20_000), total)
+	require.Zero(t, remainder)
+
+	// Balances this large would overflow an int64 once multiplied by the rate
+	amount, remainder = dailyInterest(9_000_000_000_000_000_000, 500, 0)
+	require.Equal(t, int64(1_232_876_712_328_767), amount)
+	require.Equal(t, int64(450_000), remainder)
+
+	// Empty and overdrawn balances earn nothing and keep the carried fraction
+	amount, remainder = dailyInterest(0, 200, 42)
+	require.Zero(t, amount)
+	require.Equal(t, int64(42), remainder)
+
+	amount, remainder = dailyInterest(-1_000, 200, 42)
+	require.Zero(t, amount)
+	require.Equal(t, int64(42), remainder)
+}
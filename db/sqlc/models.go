@@ -5,9 +5,11 @@
 package db
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Account struct {
@@ -16,6 +18,61 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// active, frozen or closed, only active accounts can send or receive money
+	Status string `json:"status"`
+	// sum of the pending holds of the account
+	HeldAmount int64 `json:"held_amount"`
+	// balance which can still be spent
+	AvailableBalance int64 `json:"available_balance"`
+	// standard or premium, selects the transfer limits of the account
+	Tier string `json:"tier"`
+	// account product, selects the interest rate of the account
+	Product string `json:"product"`
+}
+
+type AccountMember struct {
+	ID        int64  `json:"id"`
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	// owner, co_owner, viewer or spender
+	Role string `json:"role"`
+	// largest amount a spender can send in one transfer, null for the other roles
+	SpendLimit pgtype.Int8 `json:"spend_limit"`
+	// invited until the user accepts, then active
+	Status     string             `json:"status"`
+	InvitedBy  string             `json:"invited_by"`
+	CreatedAt  time.Time          `json:"created_at"`
+	AcceptedAt pgtype.Timestamptz `json:"accepted_at"`
+}
+
+type AccountProduct struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// annual interest rate in basis points, 200 is 2%
+	AnnualRateBps int32     `json:"annual_rate_bps"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type AccountStatusChange struct {
+	ID         int64     `json:"id"`
+	AccountID  int64     `json:"account_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  string    `json:"changed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Currency struct {
+	// ISO 4217 alphabetic code
+	Code        string `json:"code"`
+	NumericCode int32  `json:"numeric_code"`
+	// number of digits after the decimal separator of the minor unit
+	Exponent  int32     `json:"exponent"`
+	Symbol    string    `json:"symbol"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Entry struct {
@@ -24,6 +81,176 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// null for entries which are not part of a transfer
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	// balance of the account right after this entry
+	BalanceAfter int64 `json:"balance_after"`
+}
+
+type ExchangeRate struct {
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	// fixed-point, scaled by 10^8
+	Rate      int64     `json:"rate"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type FeeCharge struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// monthly, transfer or below_minimum
+	Kind string `json:"kind"`
+	// amount actually charged, less than the fee when the account could not pay all of it
+	Amount int64 `json:"amount"`
+	// first day of the month of a monthly or below minimum fee, null for transfer fees
+	Period pgtype.Date `json:"period"`
+	// transfer to the fee revenue account, null when nothing could be charged
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	// transfer which incurred a transfer fee
+	ChargedTransferID pgtype.Int8 `json:"charged_transfer_id"`
+	CreatedAt         time.Time   `json:"created_at"`
+}
+
+type FeeSchedule struct {
+	Product  string `json:"product"`
+	Currency string `json:"currency"`
+	// maintenance fee charged every month, in minor units
+	MonthlyFee int64 `json:"monthly_fee"`
+	// fee charged with every transfer sent, in minor units
+	TransferFee int64 `json:"transfer_fee"`
+	// balance to keep at the end of the month to avoid the below minimum fee, in minor units
+	MinimumBalance int64 `json:"minimum_balance"`
+	// fee charged when the month ends below the minimum balance, in minor units
+	BelowMinimumFee int64     `json:"below_minimum_fee"`
+	UpdatedBy       string    `json:"updated_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type Hold struct {
+	ID          int64 `json:"id"`
+	AccountID   int64 `json:"account_id"`
+	ToAccountID int64 `json:"to_account_id"`
+	// must be positive
+	Amount int64 `json:"amount"`
+	// pending, captured, released or expired
+	Status string `json:"status"`
+	// transfer which settled the hold once captured
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	// details of the transfer made when the hold is captured
+	Memo              pgtype.Text     `json:"memo"`
+	ExternalReference pgtype.Text     `json:"external_reference"`
+	Metadata          json.RawMessage `json:"metadata"`
+	// recipient of the payment made when the hold is captured
+	Recipient pgtype.Text `json:"recipient"`
+}
+
+type IdempotencyKey struct {
+	Username    string    `json:"username"`
+	Key         string    `json:"key"`
+	RequestHash string    `json:"request_hash"`
+	Response    []byte    `json:"response"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type InterestAccrual struct {
+	ID          int64       `json:"id"`
+	AccountID   int64       `json:"account_id"`
+	AccrualDate pgtype.Date `json:"accrual_date"`
+	// balance at the end of the accrual date, in minor units
+	Balance int64 `json:"balance"`
+	// rate of the product on the accrual date
+	AnnualRateBps int32 `json:"annual_rate_bps"`
+	// interest earned on the accrual date, in minor units
+	Amount int64 `json:"amount"`
+	// fraction of a minor unit carried to the next accrual, in 1/3650000 of a minor unit
+	Remainder int64 `json:"remainder"`
+	// interest transfer which paid the accrual, null until the month-end posting
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type Payee struct {
+	ID       int64  `json:"id"`
+	Owner    string `json:"owner"`
+	Nickname string `json:"nickname"`
+	// set when the payee is an account, null when it is a user
+	ToAccountID pgtype.Int8 `json:"to_account_id"`
+	// set when the payee is a user paid into its account in the currency, null when it is an account
+	ToUsername pgtype.Text `json:"to_username"`
+	Currency   string      `json:"currency"`
+	// pending until the confirmation code is entered, then active
+	Status string `json:"status"`
+	// code emailed to the owner, null once confirmed
+	ConfirmationCode      pgtype.Text        `json:"confirmation_code"`
+	ConfirmationExpiresAt pgtype.Timestamptz `json:"confirmation_expires_at"`
+	CreatedAt             time.Time          `json:"created_at"`
+	UpdatedAt             time.Time          `json:"updated_at"`
+}
+
+type ReconciliationReport struct {
+	ID           int64 `json:"id"`
+	AccountID    int64 `json:"account_id"`
+	Balance      int64 `json:"balance"`
+	EntriesTotal int64 `json:"entries_total"`
+	// balance minus entries_total, 0 once an earlier discrepancy is resolved
+	Difference int64     `json:"difference"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type RiskDecision struct {
+	ID int64 `json:"id"`
+	// user who requested the transfer
+	Username      string `json:"username"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	// allow, review or block
+	Decision string `json:"decision"`
+	// names of the fraud rules which triggered
+	Rules []string `json:"rules"`
+	// transfer made once allowed or approved
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	// hold on the funds of a transfer waiting for a review
+	HoldID pgtype.Int8 `json:"hold_id"`
+	// approved or rejected by a banker, null until a review is resolved
+	Resolution pgtype.Text        `json:"resolution"`
+	ReviewedBy pgtype.Text        `json:"reviewed_by"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// must be positive
+	Amount int64 `json:"amount"`
+	// cron expression, null for a one-off transfer
+	Recurrence pgtype.Text `json:"recurrence"`
+	// active, dispatched, completed, failed or cancelled
+	Status string `json:"status"`
+	// null unless active
+	NextRunAt pgtype.Timestamptz `json:"next_run_at"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64     `json:"id"`
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time `json:"scheduled_at"`
+	// succeeded or failed
+	Status     string      `json:"status"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	// why the transfer was rejected, null when it succeeded
+	Error     pgtype.Text `json:"error"`
+	CreatedAt time.Time   `json:"created_at"`
 }
 
 type Session struct {
@@ -44,6 +271,71 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// credited amount in the currency of the to account
+	ToAmount int64 `json:"to_amount"`
+	// fixed-point, scaled by 10^8, null for same-currency transfers
+	ExchangeRate pgtype.Int8 `json:"exchange_rate"`
+	QuoteID      pgtype.Text `json:"quote_id"`
+	// why a banker deposited or withdrew the money, null for customer transfers
+	ReasonCode pgtype.Text `json:"reason_code"`
+	// reference of the movement in an external system, e.g. a teller receipt or the invoice paid by a customer
+	ExternalReference pgtype.Text `json:"external_reference"`
+	// transfer refunded in part or in full by this one, which keeps its exchange rate
+	ReversalOfTransferID pgtype.Int8 `json:"reversal_of_transfer_id"`
+	// free text written by the customer who sent the transfer
+	Memo pgtype.Text `json:"memo"`
+	// string map supplied by the client, null when empty
+	Metadata json.RawMessage `json:"metadata"`
+	// username or email paid by the sender of a payment, the account of the recipient is only shown to its own side when set
+	Recipient pgtype.Text `json:"recipient"`
+}
+
+type TransferBatch struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	// pending until every row is processed, then completed
+	Status   string `json:"status"`
+	RowCount int32  `json:"row_count"`
+	// sum of the amounts of the rows
+	TotalAmount int64 `json:"total_amount"`
+	// summary of the rows, set once the batch is completed
+	SucceededCount  int32              `json:"succeeded_count"`
+	FailedCount     int32              `json:"failed_count"`
+	SucceededAmount int64              `json:"succeeded_amount"`
+	CreatedAt       time.Time          `json:"created_at"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+}
+
+type TransferBatchRow struct {
+	ID      int64 `json:"id"`
+	BatchID int64 `json:"batch_id"`
+	// position of the row in the uploaded batch, from 1
+	RowNumber   int32 `json:"row_number"`
+	ToAccountID int64 `json:"to_account_id"`
+	Amount      int64 `json:"amount"`
+	// recorded as the external reference of the transfer, empty for none
+	Reference string `json:"reference"`
+	// pending, succeeded or failed
+	Status     string      `json:"status"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	// why the transfer of a failed row was rejected
+	Error     pgtype.Text `json:"error"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type TransferLimit struct {
+	// role of the owner of the from account
+	Role     string `json:"role"`
+	Tier     string `json:"tier"`
+	Currency string `json:"currency"`
+	// largest single transfer, in minor units
+	MaxAmount int64 `json:"max_amount"`
+	// largest total sent per UTC day, in minor units
+	MaxDailyAmount int64 `json:"max_daily_amount"`
+	// most transfers sent per UTC day
+	MaxDailyCount int32     `json:"max_daily_count"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type User struct {
@@ -54,7 +346,8 @@ type User struct {
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 	IsEmailVerified   bool      `json:"is_email_verified"`
-	Role              string    `json:"role"`
+	// depositor, banker or system, system users own the internal accounts of the ledger
+	Role string `json:"role"`
 }
 
 type VerifyEmail struct {
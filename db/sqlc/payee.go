@@ -0,0 +1,8 @@
+package db
+
+// Statuses of a payee
+// A pending payee can not be paid until its owner enters the code emailed by the worker
+const (
+	PayeeStatusPending = "pending"
+	PayeeStatusActive  = "active"
+)
@@ -0,0 +1,32 @@
+package db
+
+// RecipientAccountID returns the account of the recipient of a payment, or zero for any other transfer
+// It is the to account of the payment and the from account of its reversals
+func RecipientAccountID(transfer Transfer) int64 {
+	if !transfer.Recipient.Valid {
+		return 0
+	}
+
+	if transfer.ReversalOfTransferID.Valid {
+		return transfer.FromAccountID
+	}
+
+	return transfer.ToAccountID
+}
+
+// RedactRecipient hides the account of the recipient of a payment unless the transfer is viewed from that account
+// The other side only keeps the username or email it paid
+func RedactRecipient(transfer Transfer, accountID int64) Transfer {
+	recipientAccountID := RecipientAccountID(transfer)
+	if recipientAccountID == 0 || recipientAccountID == accountID {
+		return transfer
+	}
+
+	if transfer.ToAccountID == recipientAccountID {
+		transfer.ToAccountID = 0
+	} else {
+		transfer.FromAccountID = 0
+	}
+
+	return transfer
+}
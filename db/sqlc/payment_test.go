@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentRecipientIsPrivate(t *testing.T) {
+	payer := createFundedAccount(t, 1000)
+	recipient := createRandomAccount(t)
+
+	fromTime := time.Now().Add(-time.Minute)
+
+	payment, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: payer.ID,
+		ToAccountID:   recipient.ID,
+		Amount:        10,
+		TransferDetails: TransferDetails{
+			Recipient: recipient.Owner,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, recipient.Owner, payment.Transfer.Recipient.String)
+
+	// The refund of the payment keeps the recipient private as well
+	reversal, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: payment.Transfer.ID,
+		Amount:     4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, recipient.Owner, reversal.Reversal.Transfer.Recipient.String)
+
+	transfers, err := testStore.ListTransfers(context.Background(), ListTransfersParams{
+		FromAccountID: payer.ID,
+		ToAccountID:   payer.ID,
+		Limit:         5,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+
+	for _, transfer := range transfers {
+		transfer = RedactRecipient(transfer, payer.ID)
+		require.NotEqual(t, recipient.ID, transfer.FromAccountID)
+		require.NotEqual(t, recipient.ID, transfer.ToAccountID)
+		require.Equal(t, recipient.Owner, transfer.Recipient.String)
+	}
+
+	statement, err := testStore.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: payer.ID,
+		FromTime:  fromTime,
+		ToTime:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, statement.Entries, 2)
+
+	for _, entry := range statement.Entries {
+		require.False(t, entry.CounterpartAccountID.Valid)
+		require.Equal(t, recipient.Owner, entry.CounterpartOwner.String)
+	}
+
+	// The recipient still sees the account which paid them
+	statement, err = testStore.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: recipient.ID,
+		FromTime:  fromTime,
+		ToTime:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, statement.Entries, 2)
+
+	for _, entry := range statement.Entries {
+		require.Equal(t, payer.ID, entry.CounterpartAccountID.Int64)
+		require.Equal(t, payer.Owner, entry.CounterpartOwner.String)
+	}
+
+	require.Equal(t, payment.Transfer, RedactRecipient(payment.Transfer, recipient.ID))
+}
@@ -8,30 +8,132 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AcceptAccountMember(ctx context.Context, arg AcceptAccountMemberParams) (AccountMember, error)
 	// sqlc.arg(parameterName) // use the parameter name instead of default generated param name by sqlc
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error)
+	// Summarizes the rows, which must all be processed
+	CompleteTransferBatch(ctx context.Context, id int64) (TransferBatch, error)
+	ConfirmPayee(ctx context.Context, arg ConfirmPayeeParams) (Payee, error)
+	CountTransfersBetween(ctx context.Context, arg CountTransfersBetweenParams) (int64, error)
+	// The account gets the checking product when none is given
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountMember(ctx context.Context, arg CreateAccountMemberParams) (AccountMember, error)
+	CreateAccountStatusChange(ctx context.Context, arg CreateAccountStatusChangeParams) (AccountStatusChange, error)
+	CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (Currency, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFeeCharge(ctx context.Context, arg CreateFeeChargeParams) (FeeCharge, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	// Returns no row when the key is already taken and has not expired yet
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error)
+	CreateReconciliationReport(ctx context.Context, arg CreateReconciliationReportParams) (ReconciliationReport, error)
+	CreateRiskDecision(ctx context.Context, arg CreateRiskDecisionParams) (RiskDecision, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	// Does nothing when the account already exists
+	CreateSystemAccount(ctx context.Context, arg CreateSystemAccountParams) error
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error)
+	CreateTransferBatchRow(ctx context.Context, arg CreateTransferBatchRowParams) (TransferBatchRow, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) error
+	DeletePayee(ctx context.Context, id int64) error
+	// Returns no row when the row was already processed
+	FinishTransferBatchRow(ctx context.Context, arg FinishTransferBatchRowParams) (TransferBatchRow, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByOwnerCurrency(ctx context.Context, arg GetAccountByOwnerCurrencyParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	GetAccountProduct(ctx context.Context, code string) (AccountProduct, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
+	// Only the transfers sent by customers count: deposits and withdrawals have a reason code and reversals are bank corrections
+	GetDailyTransferTotal(ctx context.Context, arg GetDailyTransferTotalParams) (GetDailyTransferTotalRow, error)
+	GetEntriesTotalSince(ctx context.Context, arg GetEntriesTotalSinceParams) (int64, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (ExchangeRate, error)
+	GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLastInterestAccrual(ctx context.Context, accountID int64) (InterestAccrual, error)
+	// The balance of the account right before from_time, read from the balance after the entries around it
+	// It is only the current balance when the account has no entry since from_time
+	GetOpeningBalance(ctx context.Context, arg GetOpeningBalanceParams) (int64, error)
+	GetPayee(ctx context.Context, id int64) (Payee, error)
+	GetPeriodicFeeCharge(ctx context.Context, arg GetPeriodicFeeChargeParams) (FeeCharge, error)
+	GetRiskDecisionForUpdate(ctx context.Context, id int64) (RiskDecision, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error)
+	GetTransferBatchRowForUpdate(ctx context.Context, id int64) (TransferBatchRow, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
+	GetTransferLimit(ctx context.Context, arg GetTransferLimitParams) (TransferLimit, error)
+	// amount is debited from the original to account, to_amount is refunded to the original from account
+	GetTransferReversedAmount(ctx context.Context, reversalOfTransferID pgtype.Int8) (GetTransferReversedAmountRow, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	// Accounts whose difference between the balance and the sum of their entries changed since their last report
+	// An account never reported counts as balanced, so a known discrepancy is only alerted once
+	// and a resolved one is returned with a zero difference, after which the same drift is alerted again
+	ListAccountDiscrepancies(ctx context.Context) ([]ListAccountDiscrepanciesRow, error)
+	// The pending invitations sent to the user
+	ListAccountInvitations(ctx context.Context, username string) ([]AccountMember, error)
+	ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error)
+	// after_id is the keyset cursor, offset is only used by the deprecated page_id pagination
+	// The accounts shared with the user are listed along with its own ones
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	ListDueScheduledTransfers(ctx context.Context, limit int32) ([]ScheduledTransfer, error)
+	// after_id is the keyset cursor, offset is only used by the deprecated page_id pagination
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+	ListExpiredHolds(ctx context.Context, limit int32) ([]Hold, error)
+	// Only active accounts can pay, the fees of frozen accounts are waived
+	ListFeeBearingAccounts(ctx context.Context, arg ListFeeBearingAccountsParams) ([]Account, error)
+	ListFeeSchedules(ctx context.Context) ([]FeeSchedule, error)
+	// Closed accounts have no balance left to earn interest
+	ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]Account, error)
+	ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error)
+	ListReconciliationReports(ctx context.Context, arg ListReconciliationReportsParams) ([]ReconciliationReport, error)
+	// pending_review keeps only the transfers held until a banker resolves them
+	ListRiskDecisions(ctx context.Context, arg ListRiskDecisionsParams) ([]RiskDecision, error)
+	// Most recent runs first
+	ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error)
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	// The counterpart is the other account of the transfer, if the entry belongs to one
+	// The account of the recipient of a payment is only listed for its own entries, the other side gets the username or email it paid
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	ListTransferBatchRows(ctx context.Context, batchID int64) ([]TransferBatchRow, error)
+	// after_id is the keyset cursor, offset is only used by the deprecated page_id pagination
+	// external_reference only keeps the transfers with this reference when it is set
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	// The rows are locked, so concurrent postings can not pay the same accruals twice
+	ListUnpostedInterestAccruals(ctx context.Context, arg ListUnpostedInterestAccrualsParams) ([]InterestAccrual, error)
+	ListUserSessions(ctx context.Context, arg ListUserSessionsParams) ([]Session, error)
+	ListUsersByRole(ctx context.Context, role string) ([]User, error)
+	PostInterestAccruals(ctx context.Context, arg PostInterestAccrualsParams) error
+	ResolveRiskDecision(ctx context.Context, arg ResolveRiskDecisionParams) (RiskDecision, error)
+	SetPayeeConfirmationCode(ctx context.Context, arg SetPayeeConfirmationCodeParams) (Payee, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateCurrency(ctx context.Context, arg UpdateCurrencyParams) (Currency, error)
+	UpdateHoldStatus(ctx context.Context, arg UpdateHoldStatusParams) (Hold, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) error
+	UpdatePayee(ctx context.Context, arg UpdatePayeeParams) (Payee, error)
+	UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error)
+	UpsertFeeSchedule(ctx context.Context, arg UpsertFeeScheduleParams) (FeeSchedule, error)
 }
 
 var _ Querier = (*Queries)(nil)
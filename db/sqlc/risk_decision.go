@@ -0,0 +1,15 @@
+package db
+
+// Decisions of the fraud screening of a transfer
+// A transfer under review is held until a banker approves or rejects it
+const (
+	RiskDecisionAllow  = "allow"
+	RiskDecisionReview = "review"
+	RiskDecisionBlock  = "block"
+)
+
+// Resolutions of a review by a banker
+const (
+	RiskResolutionApproved = "approved"
+	RiskResolutionRejected = "rejected"
+)
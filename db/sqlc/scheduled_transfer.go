@@ -0,0 +1,18 @@
+package db
+
+// Statuses of a scheduled transfer
+// An active transfer runs at next_run_at. A one-off transfer is dispatched once its run is enqueued,
+// then completed or failed with the outcome of that run
+const (
+	ScheduledTransferStatusActive     = "active"
+	ScheduledTransferStatusDispatched = "dispatched"
+	ScheduledTransferStatusCompleted  = "completed"
+	ScheduledTransferStatusFailed     = "failed"
+	ScheduledTransferStatusCancelled  = "cancelled"
+)
+
+// Statuses of a scheduled transfer run
+const (
+	ScheduledTransferRunStatusSucceeded = "succeeded"
+	ScheduledTransferRunStatusFailed    = "failed"
+)
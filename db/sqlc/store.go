@@ -10,8 +10,32 @@ import (
 type Store interface {
 	Querier // allow access to all the methods which use *Queries
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	CrossCurrencyTransferTx(ctx context.Context, arg CrossCurrencyTransferTxParams) (TransferTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error)
+	ReconcileAccountsTx(ctx context.Context) (ReconcileAccountsTxResult, error)
+	AdjustBalanceTx(ctx context.Context, arg AdjustBalanceTxParams) (AdjustBalanceTxResult, error)
+	DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error)
+	WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error)
+	UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (UpdateAccountStatusTxResult, error)
+	PlaceHold(ctx context.Context, arg PlaceHoldParams) (PlaceHoldResult, error)
+	CaptureHold(ctx context.Context, arg CaptureHoldParams) (CaptureHoldResult, error)
+	ReleaseHold(ctx context.Context, arg ReleaseHoldParams) (ReleaseHoldResult, error)
+	ExpireHolds(ctx context.Context) (ExpireHoldsResult, error)
+	RunScheduledTransferTx(ctx context.Context, arg RunScheduledTransferTxParams) (RunScheduledTransferTxResult, error)
+	FailScheduledTransferRunTx(ctx context.Context, arg FailScheduledTransferRunTxParams) (FailScheduledTransferRunTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	HoldTransferForReviewTx(ctx context.Context, arg HoldTransferForReviewTxParams) (HoldTransferForReviewTxResult, error)
+	ResolveRiskDecisionTx(ctx context.Context, arg ResolveRiskDecisionTxParams) (ResolveRiskDecisionTxResult, error)
+	AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (AccrueInterestTxResult, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	ChargeMaintenanceFeesTx(ctx context.Context, arg ChargeMaintenanceFeesTxParams) (ChargeMaintenanceFeesTxResult, error)
+	CreateTransferBatchTx(ctx context.Context, arg CreateTransferBatchTxParams) (CreateTransferBatchTxResult, error)
+	RunTransferBatchRowTx(ctx context.Context, arg RunTransferBatchRowTxParams) (RunTransferBatchRowTxResult, error)
+	CreatePayeeTx(ctx context.Context, arg CreatePayeeTxParams) (CreatePayeeTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountParams) (CreateAccountTxResult, error)
+	GetIdempotentTransfer(ctx context.Context, arg TransferTxParams) (IdempotentTransferResult, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
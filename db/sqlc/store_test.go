@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/hoangtk0100/simple-bank/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,7 +18,7 @@ Deadlock reason:
     => FOR NO KEY UPDATE: tell the db that other transactions retrieve or change the record column which is not a key
 */
 func TestTransferTx(t *testing.T) {
-	account1 := createRandomAccount(t)
+	account1 := createFundedAccount(t, 1000)
 	account2 := createRandomAccount(t)
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
@@ -99,6 +101,10 @@ func TestTransferTx(t *testing.T) {
 		require.NotEmpty(t, toAccount)
 		require.Equal(t, account2.ID, toAccount.ID)
 
+		// Check the balances recorded on the entries
+		require.Equal(t, fromAccount.Balance, fromEntry.BalanceAfter)
+		require.Equal(t, toAccount.Balance, toEntry.BalanceAfter)
+
 		// Check accounts's balance
 		fmt.Println(">> tx:", fromAccount.Balance, toAccount.Balance)
 		diff1 := account1.Balance - fromAccount.Balance
@@ -131,8 +137,8 @@ Deadlock reason:
     The transaction 1 updates account 1 before account 2 while the other transaction updates account 2 before account 1
 */
 func TestTransferTxDeadLock(t *testing.T) {
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
 	// Channels - share data between channels without explicit locking
@@ -180,3 +186,220 @@ func TestTransferTxDeadLock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferTxIdempotency(t *testing.T) {
+	account1 := createFundedAccount(t, 1000)
+	account2 := createRandomAccount(t)
+	user, err := testStore.GetUser(context.Background(), account1.Owner)
+	require.NoError(t, err)
+
+	amount := int64(10)
+	arg := TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		Idempotency: &IdempotencyParams{
+			Username: user.Username,
+			Key:      util.RandomString(16),
+			TTL:      time.Minute,
+		},
+	}
+
+	// Retries with the same key run concurrently, only one of them may move the money
+	n := 5
+	errs := make(chan error)
+	results := make(chan TransferTxResult)
+	for index := 0; index < n; index++ {
+		go func() {
+			result, err := testStore.TransferTx(context.Background(), arg)
+			errs <- err
+			results <- result
+		}()
+	}
+
+	var transferID int64
+	for index := 0; index < n; index++ {
+		err := <-errs
+		require.NoError(t, err)
+
+		result := <-results
+		require.NotZero(t, result.Transfer.ID)
+		if transferID == 0 {
+			transferID = result.Transfer.ID
+		}
+		require.Equal(t, transferID, result.Transfer.ID)
+		require.Equal(t, account1.Balance-amount, result.FromAccount.Balance)
+	}
+
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-amount, updatedAccount1.Balance)
+
+	// The same key with a different request is rejected
+	arg.Amount = amount + 1
+	_, err = testStore.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyConflict)
+}
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	amount := int64(10)
+	succeeded := 5
+	account1 := createFundedAccount(t, int64(succeeded)*amount)
+	account2 := createRandomAccount(t)
+
+	// Run more concurrent transfers than the balance can cover
+	n := 10
+	errs := make(chan error)
+	for index := 0; index < n; index++ {
+		go func() {
+			_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			})
+
+			errs <- err
+		}()
+	}
+
+	failed := 0
+	for index := 0; index < n; index++ {
+		err := <-errs
+		if err != nil {
+			require.ErrorIs(t, err, ErrInsufficientFunds)
+			failed++
+		}
+	}
+	require.Equal(t, n-succeeded, failed)
+
+	// Check the balances never dropped below zero
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Zero(t, updatedAccount1.Balance)
+
+	updatedAccount2, err := testStore.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance+int64(succeeded)*amount, updatedAccount2.Balance)
+
+	// Failed transfers leave no entries behind
+	entries, err := testStore.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account1.ID,
+		Limit:     int32(n),
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, succeeded)
+}
+
+func TestCrossCurrencyTransferTx(t *testing.T) {
+	account1 := createFundedAccount(t, 1000)
+
+	// The to account must hold another currency, otherwise the FX legs are not posted
+	currency := util.EUR
+	if account1.Currency == currency {
+		currency = util.USD
+	}
+	account2, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: currency,
+	})
+	require.NoError(t, err)
+
+	arg := CrossCurrencyTransferTxParams{
+		TransferTxParams: TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        100,
+		},
+		ToAmount:     92,
+		ExchangeRate: 92_000_000,
+		QuoteID:      util.RandomString(16),
+	}
+
+	result, err := testStore.CrossCurrencyTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	transfer := result.Transfer
+	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.ToAmount, transfer.ToAmount)
+	require.True(t, transfer.ExchangeRate.Valid)
+	require.Equal(t, arg.ExchangeRate, transfer.ExchangeRate.Int64)
+	require.True(t, transfer.QuoteID.Valid)
+	require.Equal(t, arg.QuoteID, transfer.QuoteID.String)
+
+	// The from account is debited in its own currency, the to account is credited the converted amount
+	require.Equal(t, -arg.Amount, result.FromEntry.Amount)
+	require.Equal(t, arg.ToAmount, result.ToEntry.Amount)
+	require.Equal(t, account1.Balance-arg.Amount, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+arg.ToAmount, result.ToAccount.Balance)
+	require.Equal(t, result.FromAccount.Balance, result.FromEntry.BalanceAfter)
+	require.Equal(t, result.ToAccount.Balance, result.ToEntry.BalanceAfter)
+
+	// The FX accounts balance each currency of the transfer
+	require.Len(t, result.SystemEntries, 2)
+	require.Equal(t, arg.Amount, result.SystemEntries[0].Amount)
+	require.Equal(t, -arg.ToAmount, result.SystemEntries[1].Amount)
+	for _, entry := range result.SystemEntries {
+		require.Equal(t, transfer.ID, entry.TransferID.Int64)
+	}
+
+	for i, currency := range []string{account1.Currency, account2.Currency} {
+		fxAccount, err := testStore.GetAccountByOwnerCurrency(context.Background(), GetAccountByOwnerCurrencyParams{
+			Owner:    SystemFXOwner,
+			Currency: currency,
+		})
+		require.NoError(t, err)
+		require.Equal(t, fxAccount.ID, result.SystemEntries[i].AccountID)
+		require.Equal(t, fxAccount.Balance, result.SystemEntries[i].BalanceAfter)
+	}
+}
+
+func TestTransferTxSystemAccount(t *testing.T) {
+	account := createFundedAccount(t, 1000)
+
+	deposit, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID: account.ID,
+		Amount:    10,
+	})
+	require.NoError(t, err)
+
+	// Customers can not move money in or out of the internal ledger
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   deposit.Transfer.FromAccountID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrSystemAccount)
+}
+
+func TestTransferTxDetails(t *testing.T) {
+	account1 := createFundedAccount(t, 100)
+	account2 := createRandomAccount(t)
+
+	details := TransferDetails{
+		Memo:              util.RandomString(20),
+		ExternalReference: util.RandomString(12),
+		Metadata:          map[string]string{"order": util.RandomString(6)},
+	}
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          10,
+		TransferDetails: details,
+	})
+	require.NoError(t, err)
+	require.Equal(t, details.Memo, result.Transfer.Memo.String)
+	require.Equal(t, details.ExternalReference, result.Transfer.ExternalReference.String)
+	require.JSONEq(t, fmt.Sprintf(`{"order":%q}`, details.Metadata["order"]), string(result.Transfer.Metadata))
+
+	// Transfers without details keep null columns
+	result, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.False(t, result.Transfer.Memo.Valid)
+	require.False(t, result.Transfer.ExternalReference.Valid)
+	require.Nil(t, result.Transfer.Metadata)
+}
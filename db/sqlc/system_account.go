@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// System accounts are the internal side of the double-entry ledger
+// Each one is owned by a reserved user which can not log in, with one account per currency
+const (
+	// Counterpart of deposits and withdrawals
+	SystemCashOwner = "system_cash"
+	// Collects the fees charged to customers
+	SystemFeesOwner = "system_fees"
+	// Counterpart of the manual balance adjustments, until they are cleared
+	SystemSuspenseOwner = "system_suspense"
+	// Position of the bank in each currency after cross-currency transfers
+	SystemFXOwner = "system_fx"
+	// Pays the interest earned by the customers
+	SystemInterestOwner = "system_interest"
+)
+
+var ErrSystemAccount = errors.New("system accounts can not be used in customer transfers")
+
+// IsSystemAccount reports whether the account belongs to the internal ledger
+func IsSystemAccount(account Account) bool {
+	switch account.Owner {
+	case SystemCashOwner, SystemFeesOwner, SystemSuspenseOwner, SystemFXOwner, SystemInterestOwner:
+		return true
+	}
+
+	return false
+}
+
+// getSystemAccount returns the system account of the owner in the currency, creating it on first use
+func getSystemAccount(ctx context.Context, q *Queries, owner string, currency string) (Account, error) {
+	err := q.CreateSystemAccount(ctx, CreateSystemAccountParams{
+		Owner:    owner,
+		Currency: currency,
+	})
+	if err != nil {
+		return Account{}, err
+	}
+
+	return q.GetAccountByOwnerCurrency(ctx, GetAccountByOwnerCurrencyParams{
+		Owner:    owner,
+		Currency: currency,
+	})
+}
@@ -7,26 +7,80 @@ package db
 
 import (
 	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countTransfersBetween = `-- name: CountTransfersBetween :one
+SELECT COUNT(*) FROM transfers
+WHERE
+	from_account_id = $1 AND
+	to_account_id = $2
+`
+
+type CountTransfersBetweenParams struct {
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+}
+
+func (q *Queries) CountTransfersBetween(ctx context.Context, arg CountTransfersBetweenParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTransfersBetween, arg.FromAccountID, arg.ToAccountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
 	from_account_id,
 	to_account_id,
-	amount
+	amount,
+	to_amount,
+	exchange_rate,
+	quote_id,
+	reason_code,
+	external_reference,
+	reversal_of_transfer_id,
+	memo,
+	metadata,
+	recipient
 ) VALUES (
-	$1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+	$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id, reason_code, external_reference, reversal_of_transfer_id, memo, metadata, recipient
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID        int64           `json:"from_account_id"`
+	ToAccountID          int64           `json:"to_account_id"`
+	Amount               int64           `json:"amount"`
+	ToAmount             int64           `json:"to_amount"`
+	ExchangeRate         pgtype.Int8     `json:"exchange_rate"`
+	QuoteID              pgtype.Text     `json:"quote_id"`
+	ReasonCode           pgtype.Text     `json:"reason_code"`
+	ExternalReference    pgtype.Text     `json:"external_reference"`
+	ReversalOfTransferID pgtype.Int8     `json:"reversal_of_transfer_id"`
+	Memo                 pgtype.Text     `json:"memo"`
+	Metadata             json.RawMessage `json:"metadata"`
+	Recipient            pgtype.Text     `json:"recipient"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRow(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+		arg.QuoteID,
+		arg.ReasonCode,
+		arg.ExternalReference,
+		arg.ReversalOfTransferID,
+		arg.Memo,
+		arg.Metadata,
+		arg.Recipient,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -34,12 +88,51 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
+		&i.ReasonCode,
+		&i.ExternalReference,
+		&i.ReversalOfTransferID,
+		&i.Memo,
+		&i.Metadata,
+		&i.Recipient,
 	)
 	return i, err
 }
 
+const getDailyTransferTotal = `-- name: GetDailyTransferTotal :one
+SELECT
+	COALESCE(SUM(amount), 0)::bigint AS amount,
+	COUNT(*) AS count
+FROM transfers
+WHERE
+	from_account_id = $1 AND
+	created_at >= $2 AND
+	reason_code IS NULL AND
+	reversal_of_transfer_id IS NULL
+`
+
+type GetDailyTransferTotalParams struct {
+	FromAccountID int64     `json:"from_account_id"`
+	Since         time.Time `json:"since"`
+}
+
+type GetDailyTransferTotalRow struct {
+	Amount int64 `json:"amount"`
+	Count  int64 `json:"count"`
+}
+
+// Only the transfers sent by customers count: deposits and withdrawals have a reason code and reversals are bank corrections
+func (q *Queries) GetDailyTransferTotal(ctx context.Context, arg GetDailyTransferTotalParams) (GetDailyTransferTotalRow, error) {
+	row := q.db.QueryRow(ctx, getDailyTransferTotal, arg.FromAccountID, arg.Since)
+	var i GetDailyTransferTotalRow
+	err := row.Scan(&i.Amount, &i.Count)
+	return i, err
+}
+
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id, reason_code, external_reference, reversal_of_transfer_id, memo, metadata, recipient FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -52,31 +145,96 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
+		&i.ReasonCode,
+		&i.ExternalReference,
+		&i.ReversalOfTransferID,
+		&i.Memo,
+		&i.Metadata,
+		&i.Recipient,
 	)
 	return i, err
 }
 
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id, reason_code, external_reference, reversal_of_transfer_id, memo, metadata, recipient FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
+		&i.ReasonCode,
+		&i.ExternalReference,
+		&i.ReversalOfTransferID,
+		&i.Memo,
+		&i.Metadata,
+		&i.Recipient,
+	)
+	return i, err
+}
+
+const getTransferReversedAmount = `-- name: GetTransferReversedAmount :one
+SELECT
+	COALESCE(SUM(amount), 0)::bigint AS amount,
+	COALESCE(SUM(to_amount), 0)::bigint AS to_amount
+FROM transfers
+WHERE reversal_of_transfer_id = $1
+`
+
+type GetTransferReversedAmountRow struct {
+	Amount   int64 `json:"amount"`
+	ToAmount int64 `json:"to_amount"`
+}
+
+// amount is debited from the original to account, to_amount is refunded to the original from account
+func (q *Queries) GetTransferReversedAmount(ctx context.Context, reversalOfTransferID pgtype.Int8) (GetTransferReversedAmountRow, error) {
+	row := q.db.QueryRow(ctx, getTransferReversedAmount, reversalOfTransferID)
+	var i GetTransferReversedAmountRow
+	err := row.Scan(&i.Amount, &i.ToAmount)
+	return i, err
+}
+
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id, reason_code, external_reference, reversal_of_transfer_id, memo, metadata, recipient FROM transfers
 WHERE
-	from_account_id = $1 OR
-	to_account_id = $2
+	(from_account_id = $1 OR to_account_id = $2) AND
+	id > $3 AND
+	($4::varchar IS NULL OR external_reference = $4)
 ORDER BY id
-LIMIT $3
-OFFSET $4
+LIMIT $5
+OFFSET $6
 `
 
 type ListTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+	FromAccountID     int64       `json:"from_account_id"`
+	ToAccountID       int64       `json:"to_account_id"`
+	AfterID           int64       `json:"after_id"`
+	ExternalReference pgtype.Text `json:"external_reference"`
+	Limit             int32       `json:"limit"`
+	Offset            int32       `json:"offset"`
 }
 
+// after_id is the keyset cursor, offset is only used by the deprecated page_id pagination
+// external_reference only keeps the transfers with this reference when it is set
 func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
 	rows, err := q.db.Query(ctx, listTransfers,
 		arg.FromAccountID,
 		arg.ToAccountID,
+		arg.AfterID,
+		arg.ExternalReference,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -93,6 +251,15 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ToAmount,
+			&i.ExchangeRate,
+			&i.QuoteID,
+			&i.ReasonCode,
+			&i.ExternalReference,
+			&i.ReversalOfTransferID,
+			&i.Memo,
+			&i.Metadata,
+			&i.Recipient,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,15 @@
+package db
+
+// Statuses of a transfer batch
+// A batch is pending until the worker has processed every row
+const (
+	TransferBatchStatusPending   = "pending"
+	TransferBatchStatusCompleted = "completed"
+)
+
+// Statuses of a transfer batch row
+const (
+	TransferBatchRowStatusPending   = "pending"
+	TransferBatchRowStatusSucceeded = "succeeded"
+	TransferBatchRowStatusFailed    = "failed"
+)
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Tiers of an account, the limits of each tier are stored in the transfer_limits table
+const (
+	AccountTierStandard = "standard"
+	AccountTierPremium  = "premium"
+)
+
+// checkTransferLimits makes sure the transfer of amount out of the account fits the limits of its owner role and tier
+// The limits belong to the account rather than to the user sending the money: a co-owner or a spender is held
+// to the limits of the owner role and to daily totals shared with every member, their own spend limit is checked by AuthorizeAccount
+// It must run after the transfer is created while the account is locked, so the daily totals include the transfer
+// and concurrent transfers of the account are counted one after the other
+// An account without limits for its role, tier and currency, e.g. of a newly added currency, is not limited
+func checkTransferLimits(ctx context.Context, q *Queries, account Account, amount int64) error {
+	owner, err := q.GetUser(ctx, account.Owner)
+	if err != nil {
+		return err
+	}
+
+	limit, err := q.GetTransferLimit(ctx, GetTransferLimitParams{
+		Role:     owner.Role,
+		Tier:     account.Tier,
+		Currency: account.Currency,
+	})
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if amount > limit.MaxAmount {
+		return fmt.Errorf("%w: at most %d %s (minor units) per transfer", ErrTransferLimitExceeded, limit.MaxAmount, limit.Currency)
+	}
+
+	total, err := q.GetDailyTransferTotal(ctx, GetDailyTransferTotalParams{
+		FromAccountID: account.ID,
+		Since:         time.Now().UTC().Truncate(24 * time.Hour),
+	})
+	if err != nil {
+		return err
+	}
+
+	if total.Amount > limit.MaxDailyAmount {
+		return fmt.Errorf("%w: at most %d %s (minor units) per day", ErrTransferLimitExceeded, limit.MaxDailyAmount, limit.Currency)
+	}
+
+	if total.Count > int64(limit.MaxDailyCount) {
+		return fmt.Errorf("%w: at most %d transfers per day", ErrTransferLimitExceeded, limit.MaxDailyCount)
+	}
+
+	return nil
+}
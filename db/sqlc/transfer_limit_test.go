@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func getAccountTransferLimit(t *testing.T, account Account) TransferLimit {
+	limit, err := testStore.GetTransferLimit(context.Background(), GetTransferLimitParams{
+		Role:     util.DepositorRole,
+		Tier:     account.Tier,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountTierStandard, account.Tier)
+
+	return limit
+}
+
+// setAccountBalance funds the account once its limits are known
+func setAccountBalance(t *testing.T, account Account, balance int64) Account {
+	account, err := testStore.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: balance,
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
+func TestTransferTxMaxAmount(t *testing.T) {
+	account1 := createRandomAccount(t)
+	limit := getAccountTransferLimit(t, account1)
+	account1 = setAccountBalance(t, account1, limit.MaxAmount+1)
+	account2 := createRandomAccount(t)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        limit.MaxAmount + 1,
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        limit.MaxAmount,
+	})
+	require.NoError(t, err)
+}
+
+func TestTransferTxMaxDailyAmount(t *testing.T) {
+	account1 := createRandomAccount(t)
+	limit := getAccountTransferLimit(t, account1)
+	account1 = setAccountBalance(t, account1, limit.MaxDailyAmount+1)
+	account2 := createRandomAccount(t)
+
+	// Sent in transfers of the largest allowed amount, the last one goes over the daily total
+	sent := int64(0)
+	for sent < limit.MaxDailyAmount {
+		amount := limit.MaxAmount
+		if sent+amount > limit.MaxDailyAmount {
+			amount = limit.MaxDailyAmount - sent
+		}
+
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+		sent += amount
+	}
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	// Deposits and withdrawals made by bankers are not limited
+	_, err = testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:  account1.ID,
+		Amount:     1,
+		ReasonCode: util.CashReason,
+	})
+	require.NoError(t, err)
+}
+
+func TestTransferTxMaxDailyCount(t *testing.T) {
+	account1 := createRandomAccount(t)
+	limit := getAccountTransferLimit(t, account1)
+	account1 = setAccountBalance(t, account1, int64(limit.MaxDailyCount)+1)
+	account2 := createRandomAccount(t)
+
+	for i := 0; i < int(limit.MaxDailyCount); i++ {
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        1,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+}
+
+func TestRunScheduledTransferTxMemberLimits(t *testing.T) {
+	account1 := createRandomAccount(t)
+	limit := getAccountTransferLimit(t, account1)
+	account1 = setAccountBalance(t, account1, limit.MaxAmount+1)
+	account2 := createRandomAccount(t)
+
+	// A spender without a spend limit of its own
+	member := createRandomAccountMember(t, account1, AccountMemberRoleSpender, pgtype.Int8{})
+	_, err := testStore.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account1.ID,
+		Username:  member.Username,
+	})
+	require.NoError(t, err)
+
+	scheduledTransfer, err := testStore.CreateScheduledTransfer(context.Background(), CreateScheduledTransferParams{
+		Owner:         member.Username,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        limit.MaxAmount + 1,
+		NextRunAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+	})
+	require.NoError(t, err)
+
+	// The member sending the money is held to the limits of the account owner
+	_, err = testStore.RunScheduledTransferTx(context.Background(), RunScheduledTransferTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         time.Now(),
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+}
@@ -6,14 +6,17 @@ import (
 	"time"
 
 	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
 func createRandomTransfer(t *testing.T, fromAccount, toAccount Account) Transfer {
+	amount := util.RandomMoney()
 	arg := CreateTransferParams{
 		FromAccountID: fromAccount.ID,
 		ToAccountID:   toAccount.ID,
-		Amount:        util.RandomMoney(),
+		Amount:        amount,
+		ToAmount:      amount,
 	}
 
 	transfer, err := testStore.CreateTransfer(context.Background(), arg)
@@ -23,6 +26,9 @@ func createRandomTransfer(t *testing.T, fromAccount, toAccount Account) Transfer
 	require.Equal(t, arg.FromAccountID, transfer.FromAccountID)
 	require.Equal(t, arg.ToAccountID, transfer.ToAccountID)
 	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.ToAmount, transfer.ToAmount)
+	require.False(t, transfer.ExchangeRate.Valid)
+	require.False(t, transfer.QuoteID.Valid)
 
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
@@ -77,3 +83,38 @@ func TestListTransfers(t *testing.T) {
 		require.True(t, transfer.FromAccountID == account1.ID || transfer.ToAccountID == account1.ID)
 	}
 }
+
+func TestListTransfersByExternalReference(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	reference := util.RandomString(12)
+	for index := 0; index < 3; index++ {
+		createRandomTransfer(t, account1, account2)
+	}
+
+	transfer, err := testStore.CreateTransfer(context.Background(), CreateTransferParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		ToAmount:      10,
+		ExternalReference: pgtype.Text{
+			String: reference,
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	transfers, err := testStore.ListTransfers(context.Background(), ListTransfersParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account1.ID,
+		ExternalReference: pgtype.Text{
+			String: reference,
+			Valid:  true,
+		},
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, transfer.ID, transfers[0].ID)
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AccountStatementTxParams contains the input parameters of the account statement transaction
+// Entries created in [FromTime, ToTime) are part of the statement
+type AccountStatementTxParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+// AccountStatementTxResult is the result of the account statement transaction
+type AccountStatementTxResult struct {
+	Account        Account                   `json:"account"`
+	OpeningBalance int64                     `json:"opening_balance"`
+	ClosingBalance int64                     `json:"closing_balance"`
+	Entries        []ListStatementEntriesRow `json:"entries"`
+}
+
+// AccountStatementTx reads the entries of an account for a period with the balances around it
+// All the reads share one snapshot, so the balances always match the listed entries
+func (store *SQLStore) AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error) {
+	var result AccountStatementTxResult
+
+	txOptions := pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	}
+
+	err := store.execTxWithOptions(ctx, txOptions, func(q *Queries) error {
+		var err error
+
+		result.Account, err = q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// The entries record the balance after them, which also covers the balances set without any entry
+		// by the old UpdateAccount, so the opening balance is not derived from the current balance
+		result.OpeningBalance, err = q.GetOpeningBalance(ctx, GetOpeningBalanceParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.FromTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Entries, err = q.ListStatementEntries(ctx, ListStatementEntriesParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.FromTime,
+			ToTime:    arg.ToTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ClosingBalance = result.OpeningBalance
+		for _, entry := range result.Entries {
+			result.ClosingBalance += entry.Amount
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatementTx(t *testing.T) {
+	account1 := createFundedAccount(t, 1000)
+	account2 := createRandomAccount(t)
+	account3 := createFundedAccount(t, 1000)
+
+	fromTime := time.Now().Add(-time.Minute)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account3.ID,
+		ToAccountID:   account1.ID,
+		Amount:        5,
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  fromTime,
+		ToTime:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account1.ID, result.Account.ID)
+	require.Equal(t, int64(1000), result.OpeningBalance)
+	require.Equal(t, int64(995), result.ClosingBalance)
+	require.Len(t, result.Entries, 2)
+
+	require.Equal(t, int64(-10), result.Entries[0].Amount)
+	require.True(t, result.Entries[0].TransferID.Valid)
+	require.Equal(t, account2.ID, result.Entries[0].CounterpartAccountID.Int64)
+	require.Equal(t, account2.Owner, result.Entries[0].CounterpartOwner.String)
+
+	require.Equal(t, int64(5), result.Entries[1].Amount)
+	require.Equal(t, account3.ID, result.Entries[1].CounterpartAccountID.Int64)
+
+	// A period after the transfers has no entries and both balances equal the current one
+	result, err = testStore.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  time.Now().Add(time.Minute),
+		ToTime:    time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Entries)
+	require.Equal(t, int64(995), result.OpeningBalance)
+	require.Equal(t, int64(995), result.ClosingBalance)
+
+	// A balance set without any entry, as UpdateAccount did before the ledger, does not shift the earlier statements
+	_, err = testStore.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account1.ID,
+		Balance: 2000,
+	})
+	require.NoError(t, err)
+
+	result, err = testStore.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  fromTime,
+		ToTime:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), result.OpeningBalance)
+	require.Equal(t, int64(995), result.ClosingBalance)
+}
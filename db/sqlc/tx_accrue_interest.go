@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// AccrueInterestTxParams contains the input parameters of the accrue interest transaction
+type AccrueInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Last day to accrue, in UTC, it must be over so its closing balance is known
+	Through time.Time `json:"through"`
+}
+
+// AccrueInterestTxResult is the result of the accrue interest transaction
+type AccrueInterestTxResult struct {
+	Account  Account           `json:"account"`
+	Accruals []InterestAccrual `json:"accruals"`
+}
+
+// AccrueInterestTx records the daily interest earned by an account up to a day, without paying it
+// Days which are already accrued are skipped, so a failed run is safe to retry
+func (store *SQLStore) AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (AccrueInterestTxResult, error) {
+	var result AccrueInterestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Account, result.Accruals, err = accrueInterest(ctx, q, arg.AccountID, arg.Through)
+		return err
+	})
+
+	return result, err
+}
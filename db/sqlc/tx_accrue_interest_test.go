@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// createSavingsAccount creates a random account of the savings product holding 10,000.00 deposited in cash
+func createSavingsAccount(t *testing.T) Account {
+	user := createRandomUser(t)
+
+	account, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Currency: util.RandomCurrency(),
+		Product: pgtype.Text{
+			String: AccountProductSavings,
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountProductSavings, account.Product)
+
+	result, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:  account.ID,
+		Amount:     1_000_000,
+		ReasonCode: util.CashReason,
+	})
+	require.NoError(t, err)
+
+	return result.Account
+}
+
+func TestCreateAccountDefaultProduct(t *testing.T) {
+	account := createRandomAccount(t)
+	require.Equal(t, AccountProductChecking, account.Product)
+}
+
+func TestAccrueInterestTx(t *testing.T) {
+	account := createSavingsAccount(t)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	// Nothing is accrued before the account exists
+	result, err := testStore.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID: account.ID,
+		Through:   today.AddDate(0, 0, -1),
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Accruals)
+
+	result, err = testStore.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID: account.ID,
+		Through:   today,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Accruals, 1)
+
+	accrual := result.Accruals[0]
+	require.Equal(t, account.ID, accrual.AccountID)
+	require.True(t, today.Equal(accrual.AccrualDate.Time))
+	require.Equal(t, account.Balance, accrual.Balance)
+	require.Equal(t, int32(200), accrual.AnnualRateBps)
+	require.Equal(t, int64(54), accrual.Amount)
+	require.Equal(t, int64(2_900_000), accrual.Remainder)
+	require.False(t, accrual.TransferID.Valid)
+
+	// The balance is not paid until the interest is posted
+	require.Equal(t, account.Balance, result.Account.Balance)
+
+	// Accrued days are skipped
+	result, err = testStore.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID: account.ID,
+		Through:   today,
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Accruals)
+}
+
+func TestAccrueInterestTxCheckingAccount(t *testing.T) {
+	account := createRandomAccount(t)
+
+	result, err := testStore.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID: account.ID,
+		Through:   time.Now(),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Accruals, 1)
+	require.Zero(t, result.Accruals[0].AnnualRateBps)
+	require.Zero(t, result.Accruals[0].Amount)
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+)
+
+// AdjustBalanceTxParams contains the input parameters of the adjust balance transaction
+type AdjustBalanceTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Balance   int64 `json:"balance"`
+}
+
+// AdjustBalanceTxResult is the result of the adjust balance transaction
+type AdjustBalanceTxResult struct {
+	Account Account `json:"account"`
+	// Empty when the balance is unchanged
+	Entry Entry `json:"entry"`
+}
+
+// AdjustBalanceTx sets the balance of an account and records the difference as a transfer with the suspense account,
+// so the balance always stays the sum of the account entries and the ledger of the currency stays balanced
+func (store *SQLStore) AdjustBalanceTx(ctx context.Context, arg AdjustBalanceTxParams) (AdjustBalanceTxResult, error) {
+	var result AdjustBalanceTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if IsSystemAccount(account) {
+			return ErrSystemAccount
+		}
+
+		result.Account = account
+		if account.Balance == arg.Balance {
+			return nil
+		}
+
+		suspense, err := getSystemAccount(ctx, q, SystemSuspenseOwner, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		transfer := CreateTransferParams{
+			FromAccountID: suspense.ID,
+			ToAccountID:   account.ID,
+			Amount:        arg.Balance - account.Balance,
+		}
+		if transfer.Amount < 0 {
+			transfer.FromAccountID, transfer.ToAccountID = account.ID, suspense.ID
+			transfer.Amount = -transfer.Amount
+		}
+		transfer.ToAmount = transfer.Amount
+
+		posted, err := postTransfer(ctx, q, transfer, true)
+		if err != nil {
+			return err
+		}
+
+		if posted.ToAccount.ID == account.ID {
+			result.Account, result.Entry = posted.ToAccount, posted.ToEntry
+		} else {
+			result.Account, result.Entry = posted.FromAccount, posted.FromEntry
+		}
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdjustBalanceTx(t *testing.T) {
+	account := createRandomAccount(t)
+	balance := account.Balance + 100
+
+	result, err := testStore.AdjustBalanceTx(context.Background(), AdjustBalanceTxParams{
+		AccountID: account.ID,
+		Balance:   balance,
+	})
+	require.NoError(t, err)
+	require.Equal(t, balance, result.Account.Balance)
+
+	require.NotZero(t, result.Entry.ID)
+	require.Equal(t, account.ID, result.Entry.AccountID)
+	require.Equal(t, int64(100), result.Entry.Amount)
+	require.Equal(t, balance, result.Entry.BalanceAfter)
+
+	// The difference comes from the suspense account
+	transfer, err := testStore.GetTransfer(context.Background(), result.Entry.TransferID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, transfer.ToAccountID)
+
+	suspense, err := testStore.GetAccount(context.Background(), transfer.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, SystemSuspenseOwner, suspense.Owner)
+
+	// An unchanged balance records nothing
+	result, err = testStore.AdjustBalanceTx(context.Background(), AdjustBalanceTxParams{
+		AccountID: account.ID,
+		Balance:   balance,
+	})
+	require.NoError(t, err)
+	require.Equal(t, balance, result.Account.Balance)
+	require.Zero(t, result.Entry.ID)
+}
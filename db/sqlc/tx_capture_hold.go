@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CaptureHoldParams contains the input parameters of the capture hold transaction
+type CaptureHoldParams struct {
+	HoldID int64 `json:"hold_id"`
+}
+
+// CaptureHoldResult is the result of the capture hold transaction
+type CaptureHoldResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// CaptureHold settles a pending hold with a transfer of the held amount to the account chosen when it was placed
+// The transfer follows the same rules as TransferTx, e.g. both accounts must still be active and the sender is charged the transfer fee.
+// It is not fraud screened again, a hold placed for a review was screened when the transfer was requested
+func (store *SQLStore) CaptureHold(ctx context.Context, arg CaptureHoldParams) (CaptureHoldResult, error) {
+	var result CaptureHoldResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = captureHold(ctx, q, arg.HoldID)
+		return err
+	})
+
+	return result, err
+}
+
+func captureHold(ctx context.Context, q *Queries, holdID int64) (CaptureHoldResult, error) {
+	var result CaptureHoldResult
+
+	hold, err := q.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return result, err
+	}
+
+	// An expired hold which the worker did not release yet can not be captured either
+	if hold.Status != HoldStatusPending || time.Now().After(hold.ExpiresAt) {
+		return result, ErrHoldNotPending
+	}
+
+	// Lock both accounts by ascending ID as the transfer does, the from account is updated before the transfer
+	err = lockAccounts(ctx, q, hold.AccountID, hold.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	_, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+		ID:     hold.AccountID,
+		Amount: -hold.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Transfer, err = postTransfer(ctx, q, CreateTransferParams{
+		FromAccountID:     hold.AccountID,
+		ToAccountID:       hold.ToAccountID,
+		Amount:            hold.Amount,
+		ToAmount:          hold.Amount,
+		Memo:              hold.Memo,
+		ExternalReference: hold.ExternalReference,
+		Metadata:          hold.Metadata,
+		Recipient:         hold.Recipient,
+	}, false)
+	if err != nil {
+		return result, err
+	}
+
+	err = chargeTransferFee(ctx, q, &result.Transfer)
+	if err != nil {
+		return result, err
+	}
+
+	result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+		ID:     hold.ID,
+		Status: HoldStatusCaptured,
+		TransferID: pgtype.Int8{
+			Int64: result.Transfer.Transfer.ID,
+			Valid: true,
+		},
+	})
+	return result, err
+}
+
+// lockAccounts locks the accounts by ascending ID, so it can not deadlock with a transfer between them
+func lockAccounts(ctx context.Context, q *Queries, accountID1 int64, accountID2 int64) error {
+	if accountID1 > accountID2 {
+		accountID1, accountID2 = accountID2, accountID1
+	}
+
+	_, err := q.GetAccountForUpdate(ctx, accountID1)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.GetAccountForUpdate(ctx, accountID2)
+	return err
+}
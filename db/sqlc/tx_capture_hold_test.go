@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureHold(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createFundedAccount(t, 0)
+	hold := createRandomHold(t, account, toAccount, 60, time.Now().Add(time.Hour))
+
+	result, err := testStore.CaptureHold(context.Background(), CaptureHoldParams{
+		HoldID: hold.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusCaptured, result.Hold.Status)
+	require.True(t, result.Hold.TransferID.Valid)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Hold.TransferID.Int64)
+
+	// The held amount is spent by the transfer
+	require.Equal(t, int64(40), result.Transfer.FromAccount.Balance)
+	require.Zero(t, result.Transfer.FromAccount.HeldAmount)
+	require.Equal(t, int64(40), result.Transfer.FromAccount.AvailableBalance)
+	require.Equal(t, int64(60), result.Transfer.ToAccount.Balance)
+
+	// A hold is captured only once
+	_, err = testStore.CaptureHold(context.Background(), CaptureHoldParams{
+		HoldID: hold.ID,
+	})
+	require.ErrorIs(t, err, ErrHoldNotPending)
+}
+
+func TestCaptureExpiredHold(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createRandomAccount(t)
+	hold := createRandomHold(t, account, toAccount, 60, time.Now().Add(-time.Second))
+
+	_, err := testStore.CaptureHold(context.Background(), CaptureHoldParams{
+		HoldID: hold.ID,
+	})
+	require.ErrorIs(t, err, ErrHoldNotPending)
+}
+
+func TestCaptureHoldDetails(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createRandomAccount(t)
+
+	details := TransferDetails{
+		Memo:              "rent",
+		ExternalReference: "invoice-42",
+		Metadata:          map[string]string{"order": "42"},
+	}
+	placed, err := testStore.PlaceHold(context.Background(), PlaceHoldParams{
+		AccountID:       account.ID,
+		ToAccountID:     toAccount.ID,
+		Amount:          60,
+		ExpiresAt:       time.Now().Add(time.Hour),
+		TransferDetails: details,
+	})
+	require.NoError(t, err)
+
+	// The transfer made by the capture keeps the details given with the hold
+	result, err := testStore.CaptureHold(context.Background(), CaptureHoldParams{
+		HoldID: placed.Hold.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, details.Memo, result.Transfer.Transfer.Memo.String)
+	require.Equal(t, details.ExternalReference, result.Transfer.Transfer.ExternalReference.String)
+	require.JSONEq(t, `{"order":"42"}`, string(result.Transfer.Transfer.Metadata))
+}
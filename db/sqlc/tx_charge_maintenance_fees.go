@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ChargeMaintenanceFeesTxParams contains the input parameters of the charge maintenance fees transaction
+type ChargeMaintenanceFeesTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Any time within the month to charge, in UTC
+	Period time.Time `json:"period"`
+}
+
+// ChargeMaintenanceFeesTxResult is the result of the charge maintenance fees transaction
+type ChargeMaintenanceFeesTxResult struct {
+	Account Account   `json:"account"`
+	Fees    []FeeLine `json:"fees"`
+}
+
+// ChargeMaintenanceFeesTx charges the monthly fee of the account product for a month,
+// and the below minimum fee if the month ended below the minimum balance
+// A fee is charged at most once per month, so a failed run is safe to retry
+// An account which can not pay a whole fee pays what it has left, the rest is waived
+func (store *SQLStore) ChargeMaintenanceFeesTx(ctx context.Context, arg ChargeMaintenanceFeesTxParams) (ChargeMaintenanceFeesTxResult, error) {
+	var result ChargeMaintenanceFeesTxResult
+
+	periodStart := time.Date(arg.Period.Year(), arg.Period.Month(), 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+	period := pgtype.Date{
+		Time:  periodStart,
+		Valid: true,
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Account, err = q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// Accounts opened after the month are not charged for it
+		if !result.Account.CreatedAt.Before(periodEnd) {
+			return nil
+		}
+
+		schedule, err := q.GetFeeSchedule(ctx, GetFeeScheduleParams{
+			Product:  result.Account.Product,
+			Currency: result.Account.Currency,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if schedule.MonthlyFee > 0 {
+			err = chargePeriodicFee(ctx, q, &result, FeeKindMonthly, schedule.MonthlyFee, period)
+			if err != nil {
+				return err
+			}
+		}
+
+		if schedule.BelowMinimumFee > 0 {
+			// Balances are only changed by entries, so the balance at the end of the month is the current one without the later entries
+			totalSince, err := q.GetEntriesTotalSince(ctx, GetEntriesTotalSinceParams{
+				AccountID: result.Account.ID,
+				CreatedAt: periodEnd,
+			})
+			if err != nil {
+				return err
+			}
+
+			if result.Account.Balance-totalSince < schedule.MinimumBalance {
+				err = chargePeriodicFee(ctx, q, &result, FeeKindBelowMinimum, schedule.BelowMinimumFee, period)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// chargePeriodicFee charges a fee of the period unless it was already charged, capped to the available balance
+func chargePeriodicFee(ctx context.Context, q *Queries, result *ChargeMaintenanceFeesTxResult, kind string, fee int64, period pgtype.Date) error {
+	_, err := q.GetPeriodicFeeCharge(ctx, GetPeriodicFeeChargeParams{
+		AccountID: result.Account.ID,
+		Kind:      kind,
+		Period:    period,
+	})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrRecordNotFound) {
+		return err
+	}
+
+	amount := fee
+	if amount > result.Account.AvailableBalance {
+		amount = max(result.Account.AvailableBalance, 0)
+	}
+
+	line, account, err := chargeFee(ctx, q, result.Account, CreateFeeChargeParams{
+		AccountID: result.Account.ID,
+		Kind:      kind,
+		Amount:    amount,
+		Period:    period,
+	})
+	if err != nil {
+		return err
+	}
+
+	result.Account = account
+	result.Fees = append(result.Fees, line)
+	return nil
+}
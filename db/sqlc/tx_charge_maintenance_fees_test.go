@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChargeMaintenanceFeesTx(t *testing.T) {
+	schedule := createFeeSchedule(t, UpsertFeeScheduleParams{
+		MonthlyFee:      500,
+		MinimumBalance:  10_000,
+		BelowMinimumFee: 1_000,
+	})
+	account := createScheduleAccount(t, schedule, 5_000)
+
+	arg := ChargeMaintenanceFeesTxParams{
+		AccountID: account.ID,
+		Period:    time.Now(),
+	}
+
+	result, err := testStore.ChargeMaintenanceFeesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Fees, 2)
+	require.Equal(t, FeeKindMonthly, result.Fees[0].Charge.Kind)
+	require.Equal(t, int64(500), result.Fees[0].Charge.Amount)
+	require.Equal(t, FeeKindBelowMinimum, result.Fees[1].Charge.Kind)
+	require.Equal(t, int64(1_000), result.Fees[1].Charge.Amount)
+	require.Equal(t, int64(3_500), result.Account.Balance)
+
+	// The fees of a month are charged once
+	result, err = testStore.ChargeMaintenanceFeesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, result.Fees)
+	require.Equal(t, int64(3_500), result.Account.Balance)
+}
+
+func TestChargeMaintenanceFeesTxAboveMinimum(t *testing.T) {
+	schedule := createFeeSchedule(t, UpsertFeeScheduleParams{
+		MinimumBalance:  10_000,
+		BelowMinimumFee: 1_000,
+	})
+	account := createScheduleAccount(t, schedule, 20_000)
+
+	result, err := testStore.ChargeMaintenanceFeesTx(context.Background(), ChargeMaintenanceFeesTxParams{
+		AccountID: account.ID,
+		Period:    time.Now(),
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Fees)
+	require.Equal(t, int64(20_000), result.Account.Balance)
+}
+
+func TestChargeMaintenanceFeesTxWaived(t *testing.T) {
+	schedule := createFeeSchedule(t, UpsertFeeScheduleParams{
+		MonthlyFee:      500,
+		MinimumBalance:  10_000,
+		BelowMinimumFee: 1_000,
+	})
+	account := createScheduleAccount(t, schedule, 300)
+
+	// The account pays what it has left, the rest is waived
+	result, err := testStore.ChargeMaintenanceFeesTx(context.Background(), ChargeMaintenanceFeesTxParams{
+		AccountID: account.ID,
+		Period:    time.Now(),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Fees, 2)
+	require.Equal(t, int64(300), result.Fees[0].Charge.Amount)
+	require.Zero(t, result.Fees[1].Charge.Amount)
+	require.False(t, result.Fees[1].Charge.TransferID.Valid)
+	require.Zero(t, result.Account.Balance)
+}
+
+func TestChargeMaintenanceFeesTxOpenedAfterPeriod(t *testing.T) {
+	schedule := createFeeSchedule(t, UpsertFeeScheduleParams{
+		MonthlyFee: 500,
+	})
+	account := createScheduleAccount(t, schedule, 5_000)
+	now := time.Now().UTC()
+
+	result, err := testStore.ChargeMaintenanceFeesTx(context.Background(), ChargeMaintenanceFeesTxParams{
+		AccountID: account.ID,
+		Period:    now.AddDate(0, 0, -now.Day()),
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Fees)
+}
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAccountTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	result, err := testStore.CreateAccountTx(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Currency: util.RandomCurrency(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, result.Account.Owner)
+
+	member, err := testStore.GetAccountMember(context.Background(), GetAccountMemberParams{
+		AccountID: result.Account.ID,
+		Username:  user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, result.Member, member)
+	require.Equal(t, AccountMemberRoleOwner, member.Role)
+	require.Equal(t, AccountMemberStatusActive, member.Status)
+	require.True(t, member.AcceptedAt.Valid)
+}
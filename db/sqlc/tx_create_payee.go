@@ -0,0 +1,38 @@
+package db
+
+import "context"
+
+// CreatePayeeTxParams contains the input parameters of the create payee transaction
+type CreatePayeeTxParams struct {
+	CreatePayeeParams
+	// AfterCreate is optional, it enqueues the confirmation email of a pending payee
+	AfterCreate func(payee Payee) error
+}
+
+// CreatePayeeTxResult is the result of the create payee transaction
+type CreatePayeeTxResult struct {
+	Payee Payee `json:"payee"`
+}
+
+// CreatePayeeTx records a payee
+// AfterCreate runs inside the transaction, so a pending payee is not kept when its confirmation email fails to enqueue
+func (store *SQLStore) CreatePayeeTx(ctx context.Context, arg CreatePayeeTxParams) (CreatePayeeTxResult, error) {
+	var result CreatePayeeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Payee, err = q.CreatePayee(ctx, arg.CreatePayeeParams)
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterCreate == nil {
+			return nil
+		}
+
+		return arg.AfterCreate(result.Payee)
+	})
+
+	return result, err
+}
@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPayee(t *testing.T, owner string, toAccount Account, status string) Payee {
+	result, err := testStore.CreatePayeeTx(context.Background(), CreatePayeeTxParams{
+		CreatePayeeParams: CreatePayeeParams{
+			Owner:    owner,
+			Nickname: util.RandomOwner(),
+			ToAccountID: pgtype.Int8{
+				Int64: toAccount.ID,
+				Valid: true,
+			},
+			Currency: toAccount.Currency,
+			Status:   status,
+		},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, result.Payee.ID)
+
+	return result.Payee
+}
+
+func TestCreatePayeeTx(t *testing.T) {
+	owner := createRandomUser(t)
+	toAccount := createRandomAccount(t)
+
+	payee := createRandomPayee(t, owner.Username, toAccount, PayeeStatusActive)
+	require.Equal(t, owner.Username, payee.Owner)
+	require.Equal(t, toAccount.ID, payee.ToAccountID.Int64)
+	require.False(t, payee.ToUsername.Valid)
+	require.Equal(t, toAccount.Currency, payee.Currency)
+	require.Equal(t, PayeeStatusActive, payee.Status)
+	require.False(t, payee.ConfirmationCode.Valid)
+
+	// The nickname is unique per owner
+	_, err := testStore.CreatePayeeTx(context.Background(), CreatePayeeTxParams{
+		CreatePayeeParams: CreatePayeeParams{
+			Owner:    owner.Username,
+			Nickname: payee.Nickname,
+			ToUsername: pgtype.Text{
+				String: toAccount.Owner,
+				Valid:  true,
+			},
+			Currency: toAccount.Currency,
+			Status:   PayeeStatusActive,
+		},
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+}
+
+func TestCreatePayeeTxAfterCreateError(t *testing.T) {
+	owner := createRandomUser(t)
+	toAccount := createRandomAccount(t)
+	enqueueErr := errors.New("failed to enqueue")
+
+	var payeeID int64
+	_, err := testStore.CreatePayeeTx(context.Background(), CreatePayeeTxParams{
+		CreatePayeeParams: CreatePayeeParams{
+			Owner:    owner.Username,
+			Nickname: util.RandomOwner(),
+			ToAccountID: pgtype.Int8{
+				Int64: toAccount.ID,
+				Valid: true,
+			},
+			Currency: toAccount.Currency,
+			Status:   PayeeStatusPending,
+		},
+		AfterCreate: func(payee Payee) error {
+			payeeID = payee.ID
+			return enqueueErr
+		},
+	})
+	require.ErrorIs(t, err, enqueueErr)
+
+	_, err = testStore.GetPayee(context.Background(), payeeID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestConfirmPayee(t *testing.T) {
+	owner := createRandomUser(t)
+	toAccount := createRandomAccount(t)
+	payee := createRandomPayee(t, owner.Username, toAccount, PayeeStatusPending)
+
+	code := pgtype.Text{
+		String: util.RandomString(6),
+		Valid:  true,
+	}
+	payee, err := testStore.SetPayeeConfirmationCode(context.Background(), SetPayeeConfirmationCodeParams{
+		ID:               payee.ID,
+		ConfirmationCode: code,
+		ConfirmationExpiresAt: pgtype.Timestamptz{
+			Time:  time.Now().Add(time.Minute),
+			Valid: true,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, code, payee.ConfirmationCode)
+
+	_, err = testStore.ConfirmPayee(context.Background(), ConfirmPayeeParams{
+		ID: payee.ID,
+		ConfirmationCode: pgtype.Text{
+			String: "wrong",
+			Valid:  true,
+		},
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	payee, err = testStore.ConfirmPayee(context.Background(), ConfirmPayeeParams{
+		ID:               payee.ID,
+		ConfirmationCode: code,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PayeeStatusActive, payee.Status)
+	require.False(t, payee.ConfirmationCode.Valid)
+	require.False(t, payee.ConfirmationExpiresAt.Valid)
+
+	// An active payee has no code to set
+	_, err = testStore.SetPayeeConfirmationCode(context.Background(), SetPayeeConfirmationCodeParams{
+		ID:               payee.ID,
+		ConfirmationCode: code,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
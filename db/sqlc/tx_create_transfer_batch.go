@@ -0,0 +1,58 @@
+package db
+
+import "context"
+
+// CreateTransferBatchTxParams contains the input parameters of the create transfer batch transaction
+// The BatchID of the rows is set by the transaction, RowCount and TotalAmount are computed from the rows
+type CreateTransferBatchTxParams struct {
+	Owner         string                         `json:"owner"`
+	FromAccountID int64                          `json:"from_account_id"`
+	Rows          []CreateTransferBatchRowParams `json:"rows"`
+	AfterCreate   func(batch TransferBatch) error
+}
+
+// CreateTransferBatchTxResult is the result of the create transfer batch transaction
+type CreateTransferBatchTxResult struct {
+	Batch TransferBatch      `json:"batch"`
+	Rows  []TransferBatchRow `json:"rows"`
+}
+
+// CreateTransferBatchTx records a batch and its rows, all pending
+// AfterCreate runs inside the transaction, so the batch is not kept when it fails to enqueue the processing
+func (store *SQLStore) CreateTransferBatchTx(ctx context.Context, arg CreateTransferBatchTxParams) (CreateTransferBatchTxResult, error) {
+	var result CreateTransferBatchTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		var totalAmount int64
+		for _, row := range arg.Rows {
+			totalAmount += row.Amount
+		}
+
+		result.Batch, err = q.CreateTransferBatch(ctx, CreateTransferBatchParams{
+			Owner:         arg.Owner,
+			FromAccountID: arg.FromAccountID,
+			RowCount:      int32(len(arg.Rows)),
+			TotalAmount:   totalAmount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Rows = make([]TransferBatchRow, 0, len(arg.Rows))
+		for _, row := range arg.Rows {
+			row.BatchID = result.Batch.ID
+			batchRow, err := q.CreateTransferBatchRow(ctx, row)
+			if err != nil {
+				return err
+			}
+
+			result.Rows = append(result.Rows, batchRow)
+		}
+
+		return arg.AfterCreate(result.Batch)
+	})
+
+	return result, err
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTransferBatch(t *testing.T, account Account, rows []CreateTransferBatchRowParams) CreateTransferBatchTxResult {
+	result, err := testStore.CreateTransferBatchTx(context.Background(), CreateTransferBatchTxParams{
+		Owner:         account.Owner,
+		FromAccountID: account.ID,
+		Rows:          rows,
+		AfterCreate: func(batch TransferBatch) error {
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, result.Batch.ID)
+	require.Len(t, result.Rows, len(rows))
+
+	return result
+}
+
+func TestCreateTransferBatchTx(t *testing.T) {
+	account := createRandomAccount(t)
+	toAccount1 := createRandomAccount(t)
+	toAccount2 := createRandomAccount(t)
+
+	result := createRandomTransferBatch(t, account, []CreateTransferBatchRowParams{
+		{RowNumber: 1, ToAccountID: toAccount1.ID, Amount: 10, Reference: "PAY-001"},
+		{RowNumber: 2, ToAccountID: toAccount2.ID, Amount: 20},
+	})
+
+	batch := result.Batch
+	require.Equal(t, account.Owner, batch.Owner)
+	require.Equal(t, account.ID, batch.FromAccountID)
+	require.Equal(t, TransferBatchStatusPending, batch.Status)
+	require.Equal(t, int32(2), batch.RowCount)
+	require.Equal(t, int64(30), batch.TotalAmount)
+	require.False(t, batch.CompletedAt.Valid)
+
+	for i, row := range result.Rows {
+		require.Equal(t, batch.ID, row.BatchID)
+		require.Equal(t, int32(i+1), row.RowNumber)
+		require.Equal(t, TransferBatchRowStatusPending, row.Status)
+		require.False(t, row.TransferID.Valid)
+	}
+	require.Equal(t, "PAY-001", result.Rows[0].Reference)
+	require.Empty(t, result.Rows[1].Reference)
+}
+
+func TestCreateTransferBatchTxAfterCreateError(t *testing.T) {
+	account := createRandomAccount(t)
+	toAccount := createRandomAccount(t)
+	enqueueErr := errors.New("failed to enqueue")
+
+	var batchID int64
+	_, err := testStore.CreateTransferBatchTx(context.Background(), CreateTransferBatchTxParams{
+		Owner:         account.Owner,
+		FromAccountID: account.ID,
+		Rows: []CreateTransferBatchRowParams{
+			{RowNumber: 1, ToAccountID: toAccount.ID, Amount: 10},
+		},
+		AfterCreate: func(batch TransferBatch) error {
+			batchID = batch.ID
+			return enqueueErr
+		},
+	})
+	require.ErrorIs(t, err, enqueueErr)
+
+	// The batch is not kept when it could not be processed
+	_, err = testStore.GetTransferBatch(context.Background(), batchID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DepositTxParams contains the input parameters of the deposit transaction
+type DepositTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+	// One of the util reason codes
+	ReasonCode string `json:"reason_code"`
+	// Optional
+	ExternalReference string `json:"external_reference"`
+}
+
+// DepositTxResult is the result of the deposit transaction
+type DepositTxResult struct {
+	Transfer Transfer `json:"transfer"`
+	Account  Account  `json:"account"`
+	Entry    Entry    `json:"entry"`
+}
+
+// DepositTx credits an account with cash brought to the bank
+// The money comes from the cash account of the same currency, so the ledger of the currency stays balanced
+func (store *SQLStore) DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error) {
+	var result DepositTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if IsSystemAccount(account) {
+			return ErrSystemAccount
+		}
+
+		cash, err := getSystemAccount(ctx, q, SystemCashOwner, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		posted, err := postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: cash.ID,
+			ToAccountID:   account.ID,
+			Amount:        arg.Amount,
+			ToAmount:      arg.Amount,
+			ReasonCode: pgtype.Text{
+				String: arg.ReasonCode,
+				Valid:  true,
+			},
+			ExternalReference: pgtype.Text{
+				String: arg.ExternalReference,
+				Valid:  arg.ExternalReference != "",
+			},
+		}, true)
+		if err != nil {
+			return err
+		}
+
+		result.Transfer = posted.Transfer
+		result.Account = posted.ToAccount
+		result.Entry = posted.ToEntry
+		return nil
+	})
+
+	return result, err
+}
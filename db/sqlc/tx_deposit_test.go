@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositTx(t *testing.T) {
+	account := createRandomAccount(t)
+	amount := int64(100)
+
+	result, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            amount,
+		ReasonCode:        util.CashReason,
+		ExternalReference: util.RandomString(12),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+amount, result.Account.Balance)
+	require.Equal(t, amount, result.Entry.Amount)
+	require.Equal(t, result.Account.Balance, result.Entry.BalanceAfter)
+	require.Equal(t, util.CashReason, result.Transfer.ReasonCode.String)
+	require.True(t, result.Transfer.ExternalReference.Valid)
+	require.Equal(t, result.Transfer.ID, result.Entry.TransferID.Int64)
+
+	// The money comes from the cash account of the currency
+	cash, err := testStore.GetAccount(context.Background(), result.Transfer.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, SystemCashOwner, cash.Owner)
+	require.Equal(t, account.Currency, cash.Currency)
+
+	// Both entries of the transfer are written one after the other
+	entries, err := testStore.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: cash.ID,
+		AfterID:   result.Entry.ID - 2,
+		Limit:     1,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, -amount, entries[0].Amount)
+	require.Equal(t, result.Transfer.ID, entries[0].TransferID.Int64)
+}
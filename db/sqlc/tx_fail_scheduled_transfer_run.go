@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// FailScheduledTransferRunTxParams contains the input parameters of the fail scheduled transfer run transaction
+type FailScheduledTransferRunTxParams struct {
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time `json:"scheduled_at"`
+	// Why the transfer was rejected, shown to the owner
+	Error string `json:"error"`
+}
+
+// FailScheduledTransferRunTxResult is the result of the fail scheduled transfer run transaction
+type FailScheduledTransferRunTxResult struct {
+	ScheduledTransfer ScheduledTransfer    `json:"scheduled_transfer"`
+	Run               ScheduledTransferRun `json:"run"`
+}
+
+// FailScheduledTransferRunTx records the failed run due at ScheduledAt, which fails a one-off transfer
+// A run which was already recorded fails with a unique violation, so the outcome of a run is recorded only once
+func (store *SQLStore) FailScheduledTransferRunTx(ctx context.Context, arg FailScheduledTransferRunTxParams) (FailScheduledTransferRunTxResult, error) {
+	var result FailScheduledTransferRunTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Run, err = q.CreateScheduledTransferRun(ctx, CreateScheduledTransferRunParams{
+			ScheduledTransferID: arg.ScheduledTransferID,
+			ScheduledAt:         arg.ScheduledAt,
+			Status:              ScheduledTransferRunStatusFailed,
+			Error: pgtype.Text{
+				String: arg.Error,
+				Valid:  true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledTransfer, err = q.GetScheduledTransfer(ctx, arg.ScheduledTransferID)
+		if err != nil {
+			return err
+		}
+
+		// The only run of a one-off transfer failed, it is not retried by a later dispatch
+		if !result.ScheduledTransfer.Recurrence.Valid {
+			result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, UpdateScheduledTransferParams{
+				ID: result.ScheduledTransfer.ID,
+				Status: pgtype.Text{
+					String: ScheduledTransferStatusFailed,
+					Valid:  true,
+				},
+			})
+		}
+		return err
+	})
+
+	return result, err
+}
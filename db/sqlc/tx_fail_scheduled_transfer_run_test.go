@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailScheduledTransferRunTx(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createFundedAccount(t, 0)
+	scheduledTransfer := createRandomScheduledTransfer(t, account, toAccount, 30)
+
+	arg := FailScheduledTransferRunTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         time.Now().Truncate(time.Second),
+		Error:               ErrInsufficientFunds.Error(),
+	}
+
+	result, err := testStore.FailScheduledTransferRunTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferRunStatusFailed, result.Run.Status)
+	require.Equal(t, arg.Error, result.Run.Error.String)
+	require.False(t, result.Run.TransferID.Valid)
+
+	// The next runs of a recurring transfer are not affected
+	require.Equal(t, ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.True(t, result.ScheduledTransfer.NextRunAt.Valid)
+
+	// The outcome of a run is recorded only once
+	_, err = testStore.FailScheduledTransferRunTx(context.Background(), arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+}
+
+func TestFailOneOffScheduledTransferRunTx(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createFundedAccount(t, 0)
+	scheduledTransfer := createOneOffScheduledTransfer(t, account, toAccount, 30)
+
+	result, err := testStore.FailScheduledTransferRunTx(context.Background(), FailScheduledTransferRunTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         time.Now().Truncate(time.Second),
+		Error:               ErrInsufficientFunds.Error(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferRunStatusFailed, result.Run.Status)
+	require.Equal(t, ScheduledTransferStatusFailed, result.ScheduledTransfer.Status)
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// HoldTransferForReviewTxParams contains the input parameters of the hold transfer for review transaction
+// Only TransferTxParams is hashed for idempotency, so a held transfer and a direct one share the request fingerprint
+type HoldTransferForReviewTxParams struct {
+	TransferTxParams
+	Username string `json:"-"`
+	// Fraud rules which asked for the review
+	Rules []string `json:"-"`
+	// The funds are released if no banker resolved the review by then
+	ExpiresAt time.Time `json:"-"`
+}
+
+// HoldTransferForReviewTxResult is the result of the hold transfer for review transaction
+type HoldTransferForReviewTxResult struct {
+	RiskDecision RiskDecision `json:"risk_decision"`
+	Hold         Hold         `json:"hold"`
+	FromAccount  Account      `json:"from_account"`
+}
+
+// HoldTransferForReviewTx holds the amount of a transfer which the fraud screening sent for review and records the decision
+// Nothing is transferred until a banker approves it with ResolveRiskDecisionTx
+func (store *SQLStore) HoldTransferForReviewTx(ctx context.Context, arg HoldTransferForReviewTxParams) (HoldTransferForReviewTxResult, error) {
+	var result HoldTransferForReviewTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.Idempotency != nil {
+			hash, err := requestHash(arg.TransferTxParams)
+			if err != nil {
+				return err
+			}
+
+			replayed, err := claimIdempotencyKey(ctx, q, arg.Idempotency, hash, &result)
+			if err != nil {
+				return err
+			}
+
+			// A concurrent attempt of the same request may have been transferred rather than held
+			if replayed && result.Hold.ID == 0 {
+				return ErrIdempotencyKeyConflict
+			}
+			if replayed {
+				return nil
+			}
+		}
+
+		held, err := placeHold(ctx, q, PlaceHoldParams{
+			AccountID:   arg.FromAccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			ExpiresAt:   arg.ExpiresAt,
+			// The transfer made once a banker approves it keeps the details of the request
+			TransferDetails: arg.TransferDetails,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold = held.Hold
+		result.FromAccount = held.Account
+
+		result.RiskDecision, err = q.CreateRiskDecision(ctx, CreateRiskDecisionParams{
+			Username:      arg.Username,
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+			Decision:      RiskDecisionReview,
+			Rules:         arg.Rules,
+			HoldID: pgtype.Int8{
+				Int64: held.Hold.ID,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.Idempotency != nil {
+			return saveIdempotentResponse(ctx, q, arg.Idempotency, result)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func holdRandomTransferForReview(t *testing.T, account Account, toAccount Account, amount int64, expiresAt time.Time) HoldTransferForReviewTxResult {
+	result, err := testStore.HoldTransferForReviewTx(context.Background(), HoldTransferForReviewTxParams{
+		TransferTxParams: TransferTxParams{
+			FromAccountID: account.ID,
+			ToAccountID:   toAccount.ID,
+			Amount:        amount,
+		},
+		Username:  account.Owner,
+		Rules:     []string{"new_payee"},
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestHoldTransferForReviewTx(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createRandomAccount(t)
+
+	result := holdRandomTransferForReview(t, account, toAccount, 60, time.Now().Add(time.Hour))
+
+	require.Equal(t, int64(60), result.FromAccount.HeldAmount)
+	require.Equal(t, int64(40), result.FromAccount.AvailableBalance)
+	require.Equal(t, HoldStatusPending, result.Hold.Status)
+	require.Equal(t, toAccount.ID, result.Hold.ToAccountID)
+
+	decision := result.RiskDecision
+	require.NotZero(t, decision.ID)
+	require.Equal(t, account.Owner, decision.Username)
+	require.Equal(t, RiskDecisionReview, decision.Decision)
+	require.Equal(t, []string{"new_payee"}, decision.Rules)
+	require.Equal(t, result.Hold.ID, decision.HoldID.Int64)
+	require.False(t, decision.TransferID.Valid)
+	require.False(t, decision.Resolution.Valid)
+
+	decisions, err := testStore.ListRiskDecisions(context.Background(), ListRiskDecisionsParams{
+		PendingReview: true,
+		AfterID:       decision.ID - 1,
+		Limit:         5,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, decisions)
+	require.Equal(t, decision.ID, decisions[0].ID)
+}
+
+func TestHoldTransferForReviewTxInsufficientFunds(t *testing.T) {
+	account := createFundedAccount(t, 50)
+	toAccount := createRandomAccount(t)
+
+	_, err := testStore.HoldTransferForReviewTx(context.Background(), HoldTransferForReviewTxParams{
+		TransferTxParams: TransferTxParams{
+			FromAccountID: account.ID,
+			ToAccountID:   toAccount.ID,
+			Amount:        60,
+		},
+		Username:  account.Owner,
+		Rules:     []string{"new_payee"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// PlaceHoldParams contains the input parameters of the place hold transaction
+type PlaceHoldParams struct {
+	AccountID int64 `json:"account_id"`
+	// Account credited when the hold is captured
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// Recorded on the transfer made when the hold is captured
+	TransferDetails
+}
+
+// PlaceHoldResult is the result of the place hold transaction
+type PlaceHoldResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// PlaceHold reserves funds of an account until the hold is captured, released or expires
+// The held amount is no longer part of the available balance, so it can not be spent by other transfers
+func (store *SQLStore) PlaceHold(ctx context.Context, arg PlaceHoldParams) (PlaceHoldResult, error) {
+	var result PlaceHoldResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = placeHold(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+func placeHold(ctx context.Context, q *Queries, arg PlaceHoldParams) (PlaceHoldResult, error) {
+	var result PlaceHoldResult
+
+	account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if IsSystemAccount(account) {
+		return result, ErrSystemAccount
+	}
+
+	if account.Status != AccountStatusActive {
+		return result, ErrAccountNotActive
+	}
+
+	if account.AvailableBalance < arg.Amount {
+		return result, ErrInsufficientFunds
+	}
+
+	result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+		ID:     arg.AccountID,
+		Amount: arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	memo, externalReference, recipient, metadata, err := arg.columns()
+	if err != nil {
+		return result, err
+	}
+
+	result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+		AccountID:         arg.AccountID,
+		ToAccountID:       arg.ToAccountID,
+		Amount:            arg.Amount,
+		ExpiresAt:         arg.ExpiresAt,
+		Memo:              memo,
+		ExternalReference: externalReference,
+		Metadata:          metadata,
+		Recipient:         recipient,
+	})
+	return result, err
+}
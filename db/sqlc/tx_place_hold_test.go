@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomHold(t *testing.T, account Account, toAccount Account, amount int64, expiresAt time.Time) Hold {
+	result, err := testStore.PlaceHold(context.Background(), PlaceHoldParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      amount,
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+
+	hold := result.Hold
+	require.NotZero(t, hold.ID)
+	require.Equal(t, account.ID, hold.AccountID)
+	require.Equal(t, toAccount.ID, hold.ToAccountID)
+	require.Equal(t, amount, hold.Amount)
+	require.Equal(t, HoldStatusPending, hold.Status)
+	require.False(t, hold.TransferID.Valid)
+	require.WithinDuration(t, expiresAt, hold.ExpiresAt, time.Second)
+
+	return hold
+}
+
+func TestPlaceHold(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createRandomAccount(t)
+
+	createRandomHold(t, account, toAccount, 60, time.Now().Add(time.Hour))
+
+	updatedAccount, err := testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), updatedAccount.Balance)
+	require.Equal(t, int64(60), updatedAccount.HeldAmount)
+	require.Equal(t, int64(40), updatedAccount.AvailableBalance)
+
+	// Held funds can neither be held again nor transferred
+	_, err = testStore.PlaceHold(context.Background(), PlaceHoldParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      50,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        50,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
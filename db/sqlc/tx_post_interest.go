@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PostInterestTxParams contains the input parameters of the post interest transaction
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Last day to pay, in UTC, usually the last day of the month
+	Through time.Time `json:"through"`
+}
+
+// PostInterestTxResult is the result of the post interest transaction
+// Transfer is empty when there was no interest to pay
+type PostInterestTxResult struct {
+	Accruals []InterestAccrual `json:"accruals"`
+	Transfer Transfer          `json:"transfer"`
+	Account  Account           `json:"account"`
+	Entry    Entry             `json:"entry"`
+}
+
+// PostInterestTx pays the interest accrued by an account up to a day with a transfer from the interest expense account
+// The days which are not accrued yet are accrued first, so the payment covers the whole period
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, _, err := accrueInterest(ctx, q, arg.AccountID, arg.Through)
+		if err != nil {
+			return err
+		}
+
+		result.Account = account
+		through := pgtype.Date{
+			Time:  arg.Through.UTC().Truncate(24 * time.Hour),
+			Valid: true,
+		}
+
+		result.Accruals, err = q.ListUnpostedInterestAccruals(ctx, ListUnpostedInterestAccrualsParams{
+			AccountID: account.ID,
+			Through:   through,
+		})
+		if err != nil {
+			return err
+		}
+
+		var amount int64
+		for _, accrual := range result.Accruals {
+			amount += accrual.Amount
+		}
+
+		// The accruals stay unposted and are paid with the next ones
+		if amount == 0 {
+			return nil
+		}
+
+		interest, err := getSystemAccount(ctx, q, SystemInterestOwner, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		posted, err := postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: interest.ID,
+			ToAccountID:   account.ID,
+			Amount:        amount,
+			ToAmount:      amount,
+			ReasonCode: pgtype.Text{
+				String: util.InterestReason,
+				Valid:  true,
+			},
+		}, true)
+		if err != nil {
+			return err
+		}
+
+		err = q.PostInterestAccruals(ctx, PostInterestAccrualsParams{
+			TransferID: pgtype.Int8{
+				Int64: posted.Transfer.ID,
+				Valid: true,
+			},
+			AccountID: account.ID,
+			Through:   through,
+		})
+		if err != nil {
+			return err
+		}
+
+		for index := range result.Accruals {
+			result.Accruals[index].TransferID = pgtype.Int8{
+				Int64: posted.Transfer.ID,
+				Valid: true,
+			}
+		}
+
+		result.Transfer = posted.Transfer
+		result.Account = posted.ToAccount
+		result.Entry = posted.ToEntry
+		return nil
+	})
+
+	return result, err
+}
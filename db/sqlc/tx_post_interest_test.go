@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostInterestTx(t *testing.T) {
+	account := createSavingsAccount(t)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	// The missing days are accrued before being paid
+	result, err := testStore.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Through:   today,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Accruals, 1)
+	require.Equal(t, int64(54), result.Transfer.Amount)
+	require.Equal(t, util.InterestReason, result.Transfer.ReasonCode.String)
+	require.Equal(t, account.ID, result.Transfer.ToAccountID)
+	require.Equal(t, account.Balance+54, result.Account.Balance)
+	require.Equal(t, int64(54), result.Entry.Amount)
+	require.Equal(t, result.Transfer.ID, result.Accruals[0].TransferID.Int64)
+
+	// The money comes from the interest expense account of the currency
+	interest, err := testStore.GetAccount(context.Background(), result.Transfer.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, SystemInterestOwner, interest.Owner)
+	require.Equal(t, account.Currency, interest.Currency)
+
+	// Paid accruals are not paid again
+	result, err = testStore.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Through:   today,
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Accruals)
+	require.Zero(t, result.Transfer.ID)
+	require.Equal(t, account.Balance+54, result.Account.Balance)
+}
+
+func TestPostInterestTxNothingEarned(t *testing.T) {
+	account := createRandomAccount(t)
+
+	result, err := testStore.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Through:   time.Now(),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Accruals, 1)
+	require.Zero(t, result.Transfer.ID)
+	require.Equal(t, account.Balance, result.Account.Balance)
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+)
+
+// ReconcileAccountsTxResult is the result of the reconcile accounts transaction
+type ReconcileAccountsTxResult struct {
+	// New discrepancies to alert
+	Reports []ReconciliationReport `json:"reports"`
+	// Zero difference reports of accounts which are balanced again since their last discrepancy
+	Resolved []ReconciliationReport `json:"resolved"`
+}
+
+// ReconcileAccountsTx compares the balance of every account with the sum of its entries
+// and stores a report for each account where they differ, unless the same difference was already reported.
+// Balances set by UpdateAccount before the ledger have no entries, they are reported once rather than on every run.
+// An account balanced again gets a zero difference report, so a drift of the same amount later on is reported again.
+func (store *SQLStore) ReconcileAccountsTx(ctx context.Context) (ReconcileAccountsTxResult, error) {
+	var result ReconcileAccountsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// A single statement reads one snapshot, so transfers running meanwhile can not show up as discrepancies
+		discrepancies, err := q.ListAccountDiscrepancies(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.Reports = make([]ReconciliationReport, 0, len(discrepancies))
+		for _, discrepancy := range discrepancies {
+			report, err := q.CreateReconciliationReport(ctx, CreateReconciliationReportParams{
+				AccountID:    discrepancy.AccountID,
+				Balance:      discrepancy.Balance,
+				EntriesTotal: discrepancy.EntriesTotal,
+				Difference:   discrepancy.Balance - discrepancy.EntriesTotal,
+			})
+			if err != nil {
+				return err
+			}
+
+			if report.Difference == 0 {
+				result.Resolved = append(result.Resolved, report)
+				continue
+			}
+
+			result.Reports = append(result.Reports, report)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
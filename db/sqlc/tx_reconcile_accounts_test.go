@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileAccountsTx(t *testing.T) {
+	// Random accounts are created with a balance but without any entry
+	account := createRandomAccount(t)
+
+	// Balances adjusted through the ledger have no discrepancy
+	reconciled := createRandomAccount(t)
+	_, err := testStore.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      reconciled.ID,
+		Balance: 0,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.AdjustBalanceTx(context.Background(), AdjustBalanceTxParams{
+		AccountID: reconciled.ID,
+		Balance:   100,
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.ReconcileAccountsTx(context.Background())
+	require.NoError(t, err)
+
+	var found bool
+	for _, report := range result.Reports {
+		require.NotZero(t, report.ID)
+		require.NotZero(t, report.Difference)
+		require.Equal(t, report.Balance-report.EntriesTotal, report.Difference)
+		require.NotEqual(t, reconciled.ID, report.AccountID)
+
+		if report.AccountID == account.ID {
+			found = true
+			require.Equal(t, account.Balance, report.Balance)
+			require.Zero(t, report.EntriesTotal)
+		}
+	}
+	require.True(t, found)
+
+	reports, err := testStore.ListReconciliationReports(context.Background(), ListReconciliationReportsParams{
+		AfterID: result.Reports[0].ID - 1,
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, result.Reports[0].ID, reports[0].ID)
+
+	// A known discrepancy is not reported again until its difference changes
+	result, err = testStore.ReconcileAccountsTx(context.Background())
+	require.NoError(t, err)
+	for _, report := range result.Reports {
+		require.NotEqual(t, account.ID, report.AccountID)
+	}
+
+	_, err = testStore.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: account.Balance + 1,
+	})
+	require.NoError(t, err)
+
+	result, err = testStore.ReconcileAccountsTx(context.Background())
+	require.NoError(t, err)
+	found = false
+	for _, report := range result.Reports {
+		if report.AccountID == account.ID {
+			found = true
+			require.Equal(t, account.Balance+1, report.Difference)
+		}
+	}
+	require.True(t, found)
+
+	// The account is balanced again, which resolves its discrepancy
+	_, err = testStore.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: 0,
+	})
+	require.NoError(t, err)
+
+	result, err = testStore.ReconcileAccountsTx(context.Background())
+	require.NoError(t, err)
+	for _, report := range result.Reports {
+		require.NotEqual(t, account.ID, report.AccountID)
+	}
+
+	found = false
+	for _, report := range result.Resolved {
+		require.Zero(t, report.Difference)
+		if report.AccountID == account.ID {
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	// The same drift coming back after it was resolved is a new discrepancy
+	_, err = testStore.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: account.Balance + 1,
+	})
+	require.NoError(t, err)
+
+	result, err = testStore.ReconcileAccountsTx(context.Background())
+	require.NoError(t, err)
+	found = false
+	for _, report := range result.Reports {
+		if report.AccountID == account.ID {
+			found = true
+			require.Equal(t, account.Balance+1, report.Difference)
+		}
+	}
+	require.True(t, found)
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// ReleaseHoldParams contains the input parameters of the release hold transaction
+type ReleaseHoldParams struct {
+	HoldID int64 `json:"hold_id"`
+}
+
+// ReleaseHoldResult is the result of the release hold transaction
+type ReleaseHoldResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// ReleaseHold cancels a pending hold, its amount is available again
+func (store *SQLStore) ReleaseHold(ctx context.Context, arg ReleaseHoldParams) (ReleaseHoldResult, error) {
+	return store.releaseHoldTx(ctx, arg.HoldID, HoldStatusReleased)
+}
+
+// ExpireHoldsResult is the result of the expire holds transactions
+type ExpireHoldsResult struct {
+	Holds []Hold `json:"holds"`
+}
+
+// maxExpiredHolds limits the number of holds expired by a single run, the next run expires the others
+const maxExpiredHolds = 1000
+
+// ExpireHolds releases the pending holds which reached their expiry time
+// Each hold is released in its own transaction, a hold captured or released in the meantime is skipped
+func (store *SQLStore) ExpireHolds(ctx context.Context) (ExpireHoldsResult, error) {
+	var result ExpireHoldsResult
+
+	holds, err := store.ListExpiredHolds(ctx, maxExpiredHolds)
+	if err != nil {
+		return result, err
+	}
+
+	for _, hold := range holds {
+		released, err := store.releaseHoldTx(ctx, hold.ID, HoldStatusExpired)
+		if err != nil {
+			if errors.Is(err, ErrHoldNotPending) {
+				continue
+			}
+			return result, err
+		}
+
+		result.Holds = append(result.Holds, released.Hold)
+	}
+
+	return result, nil
+}
+
+func (store *SQLStore) releaseHoldTx(ctx context.Context, holdID int64, status string) (ReleaseHoldResult, error) {
+	var result ReleaseHoldResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = releaseHold(ctx, q, holdID, status)
+		return err
+	})
+
+	return result, err
+}
+
+func releaseHold(ctx context.Context, q *Queries, holdID int64, status string) (ReleaseHoldResult, error) {
+	var result ReleaseHoldResult
+
+	hold, err := q.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return result, err
+	}
+
+	if hold.Status != HoldStatusPending {
+		return result, ErrHoldNotPending
+	}
+
+	result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+		ID:     hold.AccountID,
+		Amount: -hold.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+		ID:     hold.ID,
+		Status: status,
+	})
+	return result, err
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseHold(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createRandomAccount(t)
+	hold := createRandomHold(t, account, toAccount, 60, time.Now().Add(time.Hour))
+
+	result, err := testStore.ReleaseHold(context.Background(), ReleaseHoldParams{
+		HoldID: hold.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusReleased, result.Hold.Status)
+	require.Equal(t, int64(100), result.Account.Balance)
+	require.Zero(t, result.Account.HeldAmount)
+	require.Equal(t, int64(100), result.Account.AvailableBalance)
+
+	_, err = testStore.ReleaseHold(context.Background(), ReleaseHoldParams{
+		HoldID: hold.ID,
+	})
+	require.ErrorIs(t, err, ErrHoldNotPending)
+}
+
+func TestExpireHolds(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createRandomAccount(t)
+	expiredHold := createRandomHold(t, account, toAccount, 30, time.Now().Add(-time.Second))
+	pendingHold := createRandomHold(t, account, toAccount, 30, time.Now().Add(time.Hour))
+
+	result, err := testStore.ExpireHolds(context.Background())
+	require.NoError(t, err)
+
+	expired := make(map[int64]Hold)
+	for _, hold := range result.Holds {
+		expired[hold.ID] = hold
+	}
+	require.Contains(t, expired, expiredHold.ID)
+	require.Equal(t, HoldStatusExpired, expired[expiredHold.ID].Status)
+	require.NotContains(t, expired, pendingHold.ID)
+
+	updatedAccount, err := testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, pendingHold.Amount, updatedAccount.HeldAmount)
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ResolveRiskDecisionTxParams contains the input parameters of the resolve risk decision transaction
+type ResolveRiskDecisionTxParams struct {
+	ID int64 `json:"id"`
+	// The held transfer is made when approved, its funds are released otherwise
+	Approve    bool   `json:"approve"`
+	ReviewedBy string `json:"reviewed_by"`
+}
+
+// ResolveRiskDecisionTxResult is the result of the resolve risk decision transaction
+type ResolveRiskDecisionTxResult struct {
+	RiskDecision RiskDecision `json:"risk_decision"`
+	Hold         Hold         `json:"hold"`
+	// Empty when the transfer was rejected
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// ResolveRiskDecisionTx records the review of a transfer held by the fraud screening
+// An approved transfer captures the hold, so it follows the same rules as TransferTx, e.g. the transfer limits
+func (store *SQLStore) ResolveRiskDecisionTx(ctx context.Context, arg ResolveRiskDecisionTxParams) (ResolveRiskDecisionTxResult, error) {
+	var result ResolveRiskDecisionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		decision, err := q.GetRiskDecisionForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if decision.Decision != RiskDecisionReview || decision.Resolution.Valid || !decision.HoldID.Valid {
+			return ErrRiskDecisionNotPending
+		}
+
+		update := ResolveRiskDecisionParams{
+			ID: decision.ID,
+			ReviewedBy: pgtype.Text{
+				String: arg.ReviewedBy,
+				Valid:  true,
+			},
+		}
+
+		if arg.Approve {
+			captured, err := captureHold(ctx, q, decision.HoldID.Int64)
+			if err != nil {
+				return err
+			}
+
+			result.Hold = captured.Hold
+			result.Transfer = captured.Transfer
+			update.Resolution = pgtype.Text{
+				String: RiskResolutionApproved,
+				Valid:  true,
+			}
+			update.TransferID = pgtype.Int8{
+				Int64: captured.Transfer.Transfer.ID,
+				Valid: true,
+			}
+		} else {
+			released, err := releaseHold(ctx, q, decision.HoldID.Int64, HoldStatusReleased)
+			if errors.Is(err, ErrHoldNotPending) {
+				// The hold expired before the review, its funds are already available again
+				released.Hold, err = q.GetHoldForUpdate(ctx, decision.HoldID.Int64)
+			}
+			if err != nil {
+				return err
+			}
+
+			result.Hold = released.Hold
+			update.Resolution = pgtype.Text{
+				String: RiskResolutionRejected,
+				Valid:  true,
+			}
+		}
+
+		result.RiskDecision, err = q.ResolveRiskDecision(ctx, update)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRiskDecisionTxApprove(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createFundedAccount(t, 0)
+	reviewer := createRandomUser(t)
+	held := holdRandomTransferForReview(t, account, toAccount, 60, time.Now().Add(time.Hour))
+
+	result, err := testStore.ResolveRiskDecisionTx(context.Background(), ResolveRiskDecisionTxParams{
+		ID:         held.RiskDecision.ID,
+		Approve:    true,
+		ReviewedBy: reviewer.Username,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, RiskResolutionApproved, result.RiskDecision.Resolution.String)
+	require.Equal(t, reviewer.Username, result.RiskDecision.ReviewedBy.String)
+	require.True(t, result.RiskDecision.ReviewedAt.Valid)
+	require.Equal(t, result.Transfer.Transfer.ID, result.RiskDecision.TransferID.Int64)
+	require.Equal(t, HoldStatusCaptured, result.Hold.Status)
+	require.Equal(t, int64(40), result.Transfer.FromAccount.Balance)
+	require.Zero(t, result.Transfer.FromAccount.HeldAmount)
+	require.Equal(t, int64(60), result.Transfer.ToAccount.Balance)
+
+	// A review is resolved only once
+	_, err = testStore.ResolveRiskDecisionTx(context.Background(), ResolveRiskDecisionTxParams{
+		ID:         held.RiskDecision.ID,
+		ReviewedBy: reviewer.Username,
+	})
+	require.ErrorIs(t, err, ErrRiskDecisionNotPending)
+}
+
+func TestResolveRiskDecisionTxReject(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createRandomAccount(t)
+	reviewer := createRandomUser(t)
+	held := holdRandomTransferForReview(t, account, toAccount, 60, time.Now().Add(time.Hour))
+
+	result, err := testStore.ResolveRiskDecisionTx(context.Background(), ResolveRiskDecisionTxParams{
+		ID:         held.RiskDecision.ID,
+		ReviewedBy: reviewer.Username,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, RiskResolutionRejected, result.RiskDecision.Resolution.String)
+	require.False(t, result.RiskDecision.TransferID.Valid)
+	require.Equal(t, HoldStatusReleased, result.Hold.Status)
+
+	updatedAccount, err := testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), updatedAccount.Balance)
+	require.Zero(t, updatedAccount.HeldAmount)
+}
+
+func TestResolveRiskDecisionTxRejectExpiredHold(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createRandomAccount(t)
+	reviewer := createRandomUser(t)
+	held := holdRandomTransferForReview(t, account, toAccount, 60, time.Now().Add(-time.Second))
+
+	_, err := testStore.ExpireHolds(context.Background())
+	require.NoError(t, err)
+
+	// The funds are already available, the rejection is still recorded
+	result, err := testStore.ResolveRiskDecisionTx(context.Background(), ResolveRiskDecisionTxParams{
+		ID:         held.RiskDecision.ID,
+		ReviewedBy: reviewer.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, RiskResolutionRejected, result.RiskDecision.Resolution.String)
+	require.Equal(t, HoldStatusExpired, result.Hold.Status)
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ReverseTransferTxParams contains the input parameters of the reverse transfer transaction
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Refunded to the from account of the transfer, in its currency
+	// Zero refunds everything which is not reversed yet
+	Amount int64 `json:"amount"`
+	// Optional: a retried request with the same key returns the stored result instead of reversing again
+	Idempotency *IdempotencyParams `json:"-"`
+}
+
+// ReverseTransferTxResult is the result of the reverse transfer transaction
+type ReverseTransferTxResult struct {
+	OriginalTransfer Transfer         `json:"original_transfer"`
+	Reversal         TransferTxResult `json:"reversal"`
+}
+
+// ReverseTransferTx refunds a transfer in part or in full with a compensating transfer in the opposite direction
+// The reversals of a transfer never refund more than its amount: the transfer row is locked,
+// so concurrent reversals are checked one after the other against the amount already refunded
+// A cross-currency transfer is reversed at its own rate, the last reversal debits exactly what is left
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.Idempotency != nil {
+			hash, err := requestHash(arg)
+			if err != nil {
+				return err
+			}
+
+			replayed, err := claimIdempotencyKey(ctx, q, arg.Idempotency, hash, &result)
+			if err != nil || replayed {
+				return err
+			}
+		}
+
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		if original.ReversalOfTransferID.Valid {
+			return ErrReversalOfReversal
+		}
+
+		reversalOf := pgtype.Int8{
+			Int64: original.ID,
+			Valid: true,
+		}
+
+		reversed, err := q.GetTransferReversedAmount(ctx, reversalOf)
+		if err != nil {
+			return err
+		}
+
+		remaining := original.Amount - reversed.ToAmount
+		amount := arg.Amount
+		if amount == 0 {
+			amount = remaining
+		}
+
+		if amount <= 0 || amount > remaining {
+			return ErrInvalidReversalAmount
+		}
+
+		debit := amount
+		if amount == remaining {
+			debit = original.ToAmount - reversed.Amount
+		} else if original.ToAmount != original.Amount {
+			debit = prorate(original.ToAmount, amount, original.Amount)
+		}
+
+		if debit <= 0 {
+			return ErrInvalidReversalAmount
+		}
+
+		// The compensating transfer may involve the cash account, e.g. to reverse a deposit
+		result.Reversal, err = postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID:        original.ToAccountID,
+			ToAccountID:          original.FromAccountID,
+			Amount:               debit,
+			ToAmount:             amount,
+			ExchangeRate:         original.ExchangeRate,
+			QuoteID:              original.QuoteID,
+			ReversalOfTransferID: reversalOf,
+			// The refund of a payment keeps the account of the recipient private too
+			Recipient: original.Recipient,
+		}, true)
+		if err != nil {
+			return err
+		}
+
+		result.OriginalTransfer = original
+
+		if arg.Idempotency != nil {
+			return saveIdempotentResponse(ctx, q, arg.Idempotency, result)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// prorate returns value * numerator / denominator rounded down, without overflowing int64 in between
+func prorate(value int64, numerator int64, denominator int64) int64 {
+	product := new(big.Int).Mul(big.NewInt(value), big.NewInt(numerator))
+	return product.Quo(product, big.NewInt(denominator)).Int64()
+}
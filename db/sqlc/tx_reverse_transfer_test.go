@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createFundedTransfer(t *testing.T, amount int64) (Account, Account, Transfer) {
+	account1 := createFundedAccount(t, amount)
+	account2 := createFundedAccount(t, 0)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	})
+	require.NoError(t, err)
+
+	return account1, account2, result.Transfer
+}
+
+func TestReverseTransferTx(t *testing.T) {
+	account1, account2, transfer := createFundedTransfer(t, 100)
+
+	// Partial refund
+	result, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.ID,
+		Amount:     30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, transfer.ID, result.OriginalTransfer.ID)
+
+	reversal := result.Reversal
+	require.Equal(t, account2.ID, reversal.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, reversal.Transfer.ToAccountID)
+	require.Equal(t, int64(30), reversal.Transfer.Amount)
+	require.True(t, reversal.Transfer.ReversalOfTransferID.Valid)
+	require.Equal(t, transfer.ID, reversal.Transfer.ReversalOfTransferID.Int64)
+	require.Equal(t, int64(-30), reversal.FromEntry.Amount)
+	require.Equal(t, int64(30), reversal.ToEntry.Amount)
+	require.Equal(t, int64(30), reversal.ToAccount.Balance)
+	require.Equal(t, int64(70), reversal.FromAccount.Balance)
+
+	// No more than the original amount is refunded
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.ID,
+		Amount:     71,
+	})
+	require.ErrorIs(t, err, ErrInvalidReversalAmount)
+
+	// Zero refunds the rest
+	result, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(70), result.Reversal.Transfer.Amount)
+	require.Equal(t, int64(100), result.Reversal.ToAccount.Balance)
+	require.Zero(t, result.Reversal.FromAccount.Balance)
+
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrInvalidReversalAmount)
+
+	// A reversal is final
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Reversal.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrReversalOfReversal)
+}
+
+func TestReverseTransferTxConcurrent(t *testing.T) {
+	_, account2, transfer := createFundedTransfer(t, 100)
+
+	// Only 3 of the 5 concurrent refunds fit in the original amount
+	n := 5
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID: transfer.ID,
+				Amount:     30,
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, ErrInvalidReversalAmount)
+	}
+	require.Equal(t, 3, succeeded)
+
+	account2, err := testStore.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), account2.Balance)
+}
+
+func TestReverseTransferTxInsufficientFunds(t *testing.T) {
+	_, account2, transfer := createFundedTransfer(t, 100)
+
+	// The recipient already spent the money
+	account3 := createFundedAccount(t, 0)
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account3.ID,
+		Amount:        80,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RunScheduledTransferTxParams contains the input parameters of the run scheduled transfer transaction
+type RunScheduledTransferTxParams struct {
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time `json:"scheduled_at"`
+}
+
+// RunScheduledTransferTxResult is the result of the run scheduled transfer transaction
+type RunScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer    `json:"scheduled_transfer"`
+	Run               ScheduledTransferRun `json:"run"`
+	Transfer          TransferTxResult     `json:"transfer"`
+}
+
+// RunScheduledTransferTx performs the transfer due at ScheduledAt and records the succeeded run, which completes a one-off transfer
+// The transfer follows the same rules as TransferTx and the owner must still be allowed to spend from the account. A run which was already recorded fails with a unique violation
+// and rolls back its transfer, so a retried or duplicated task never moves the money twice.
+// Runs are not fraud screened: no session is behind them, so the spend authorization and the transfer limits are what guard them
+func (store *SQLStore) RunScheduledTransferTx(ctx context.Context, arg RunScheduledTransferTxParams) (RunScheduledTransferTxResult, error) {
+	var result RunScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.ScheduledTransfer, err = q.GetScheduledTransfer(ctx, arg.ScheduledTransferID)
+		if err != nil {
+			return err
+		}
+
+		// The run may have been enqueued before the owner cancelled the transfer
+		if result.ScheduledTransfer.Status == ScheduledTransferStatusCancelled {
+			return ErrScheduledTransferCancelled
+		}
+
+		// The owner may spend as a member of the from account, who can be removed or limited after scheduling the transfer
+		fromAccount, err := q.GetAccount(ctx, result.ScheduledTransfer.FromAccountID)
+		if err != nil {
+			return err
+		}
+
+		err = AuthorizeAccount(ctx, q, AuthorizeAccountParams{
+			Account:    fromAccount,
+			Username:   result.ScheduledTransfer.Owner,
+			Permission: AccountPermissionSpend,
+			Amount:     result.ScheduledTransfer.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: result.ScheduledTransfer.FromAccountID,
+			ToAccountID:   result.ScheduledTransfer.ToAccountID,
+			Amount:        result.ScheduledTransfer.Amount,
+			ToAmount:      result.ScheduledTransfer.Amount,
+		}, false)
+		if err != nil {
+			return err
+		}
+
+		err = chargeTransferFee(ctx, q, &result.Transfer)
+		if err != nil {
+			return err
+		}
+
+		result.Run, err = q.CreateScheduledTransferRun(ctx, CreateScheduledTransferRunParams{
+			ScheduledTransferID: arg.ScheduledTransferID,
+			ScheduledAt:         arg.ScheduledAt,
+			Status:              ScheduledTransferRunStatusSucceeded,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.Transfer.ID,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// The only run of a one-off transfer completes it
+		if !result.ScheduledTransfer.Recurrence.Valid {
+			result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, UpdateScheduledTransferParams{
+				ID: result.ScheduledTransfer.ID,
+				Status: pgtype.Text{
+					String: ScheduledTransferStatusCompleted,
+					Valid:  true,
+				},
+			})
+		}
+		return err
+	})
+
+	return result, err
+}
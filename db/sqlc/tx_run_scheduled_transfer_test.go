@@ -0,0 +1,185 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomScheduledTransfer(t *testing.T, account Account, toAccount Account, amount int64) ScheduledTransfer {
+	arg := CreateScheduledTransferParams{
+		Owner:         account.Owner,
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount,
+		Recurrence: pgtype.Text{
+			String: "0 9 1 * *",
+			Valid:  true,
+		},
+		NextRunAt: pgtype.Timestamptz{
+			Time:  time.Now().Add(time.Hour),
+			Valid: true,
+		},
+	}
+
+	scheduledTransfer, err := testStore.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, scheduledTransfer.ID)
+	require.Equal(t, arg.Owner, scheduledTransfer.Owner)
+	require.Equal(t, arg.FromAccountID, scheduledTransfer.FromAccountID)
+	require.Equal(t, arg.ToAccountID, scheduledTransfer.ToAccountID)
+	require.Equal(t, arg.Amount, scheduledTransfer.Amount)
+	require.Equal(t, arg.Recurrence, scheduledTransfer.Recurrence)
+	require.Equal(t, ScheduledTransferStatusActive, scheduledTransfer.Status)
+	require.WithinDuration(t, arg.NextRunAt.Time, scheduledTransfer.NextRunAt.Time, time.Second)
+
+	return scheduledTransfer
+}
+
+func TestRunScheduledTransferTx(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createFundedAccount(t, 0)
+	scheduledTransfer := createRandomScheduledTransfer(t, account, toAccount, 30)
+	scheduledAt := time.Now().Truncate(time.Second)
+
+	arg := RunScheduledTransferTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         scheduledAt,
+	}
+
+	result, err := testStore.RunScheduledTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(70), result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(30), result.Transfer.ToAccount.Balance)
+	require.Equal(t, ScheduledTransferRunStatusSucceeded, result.Run.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Run.TransferID.Int64)
+
+	// The same run is never transferred twice
+	_, err = testStore.RunScheduledTransferTx(context.Background(), arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	account, err = testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(70), account.Balance)
+
+	runs, err := testStore.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               10,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, result.Run.ID, runs[0].ID)
+}
+
+// createOneOffScheduledTransfer creates a scheduled transfer without recurrence, as dispatched by the worker
+func createOneOffScheduledTransfer(t *testing.T, account Account, toAccount Account, amount int64) ScheduledTransfer {
+	scheduledTransfer, err := testStore.CreateScheduledTransfer(context.Background(), CreateScheduledTransferParams{
+		Owner:         account.Owner,
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount,
+		NextRunAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, scheduledTransfer.Recurrence.Valid)
+
+	scheduledTransfer, err = testStore.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID: scheduledTransfer.ID,
+		Status: pgtype.Text{
+			String: ScheduledTransferStatusDispatched,
+			Valid:  true,
+		},
+		ClearNextRunAt: true,
+	})
+	require.NoError(t, err)
+
+	return scheduledTransfer
+}
+
+func TestRunOneOffScheduledTransferTx(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createFundedAccount(t, 0)
+	scheduledTransfer := createOneOffScheduledTransfer(t, account, toAccount, 30)
+
+	result, err := testStore.RunScheduledTransferTx(context.Background(), RunScheduledTransferTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         time.Now().Truncate(time.Second),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferRunStatusSucceeded, result.Run.Status)
+
+	// The only run completes the transfer
+	require.Equal(t, ScheduledTransferStatusCompleted, result.ScheduledTransfer.Status)
+	require.False(t, result.ScheduledTransfer.NextRunAt.Valid)
+}
+
+func TestRunCancelledScheduledTransferTx(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createRandomAccount(t)
+	scheduledTransfer := createRandomScheduledTransfer(t, account, toAccount, 30)
+
+	scheduledTransfer, err := testStore.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID: scheduledTransfer.ID,
+		Status: pgtype.Text{
+			String: ScheduledTransferStatusCancelled,
+			Valid:  true,
+		},
+		ClearNextRunAt: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferStatusCancelled, scheduledTransfer.Status)
+	require.False(t, scheduledTransfer.NextRunAt.Valid)
+
+	_, err = testStore.RunScheduledTransferTx(context.Background(), RunScheduledTransferTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         time.Now(),
+	})
+	require.ErrorIs(t, err, ErrScheduledTransferCancelled)
+}
+
+func TestRunScheduledTransferTxMemberRemoved(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createFundedAccount(t, 0)
+	member := createRandomAccountMember(t, account, AccountMemberRoleSpender, pgtype.Int8{})
+
+	_, err := testStore.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.NoError(t, err)
+
+	scheduledTransfer, err := testStore.CreateScheduledTransfer(context.Background(), CreateScheduledTransferParams{
+		Owner:         member.Username,
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        30,
+		NextRunAt: pgtype.Timestamptz{
+			Time:  time.Now().Add(time.Hour),
+			Valid: true,
+		},
+	})
+	require.NoError(t, err)
+
+	// The member was removed after scheduling the transfer
+	err = testStore.DeleteAccountMember(context.Background(), DeleteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.RunScheduledTransferTx(context.Background(), RunScheduledTransferTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         time.Now(),
+	})
+	require.ErrorIs(t, err, ErrAccountAccessDenied)
+
+	account, err = testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account.Balance)
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RunTransferBatchRowTxParams contains the input parameters of the run transfer batch row transaction
+type RunTransferBatchRowTxParams struct {
+	RowID int64 `json:"row_id"`
+}
+
+// RunTransferBatchRowTxResult is the result of the run transfer batch row transaction
+type RunTransferBatchRowTxResult struct {
+	Row      TransferBatchRow `json:"row"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// RunTransferBatchRowTx performs the transfer of a pending row and marks it as succeeded
+// The transfer follows the same rules as TransferTx and records the reference of the row as its external reference.
+// The owner of the batch must still be allowed to spend the amount of the row from the account.
+// The row is locked first, so a retried or duplicated task fails with ErrTransferBatchRowProcessed instead of moving the money twice.
+// Rows are not fraud screened: no session is behind them, so the spend authorization and the transfer limits are what guard them
+func (store *SQLStore) RunTransferBatchRowTx(ctx context.Context, arg RunTransferBatchRowTxParams) (RunTransferBatchRowTxResult, error) {
+	var result RunTransferBatchRowTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		row, err := q.GetTransferBatchRowForUpdate(ctx, arg.RowID)
+		if err != nil {
+			return err
+		}
+
+		if row.Status != TransferBatchRowStatusPending {
+			return ErrTransferBatchRowProcessed
+		}
+
+		batch, err := q.GetTransferBatch(ctx, row.BatchID)
+		if err != nil {
+			return err
+		}
+
+		// The owner may spend as a member of the from account, who can be removed or limited while the batch runs
+		fromAccount, err := q.GetAccount(ctx, batch.FromAccountID)
+		if err != nil {
+			return err
+		}
+
+		err = AuthorizeAccount(ctx, q, AuthorizeAccountParams{
+			Account:    fromAccount,
+			Username:   batch.Owner,
+			Permission: AccountPermissionSpend,
+			Amount:     row.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: batch.FromAccountID,
+			ToAccountID:   row.ToAccountID,
+			Amount:        row.Amount,
+			ToAmount:      row.Amount,
+			ExternalReference: pgtype.Text{
+				String: row.Reference,
+				Valid:  row.Reference != "",
+			},
+		}, false)
+		if err != nil {
+			return err
+		}
+
+		err = chargeTransferFee(ctx, q, &result.Transfer)
+		if err != nil {
+			return err
+		}
+
+		result.Row, err = q.FinishTransferBatchRow(ctx, FinishTransferBatchRowParams{
+			ID:     row.ID,
+			Status: TransferBatchRowStatusSucceeded,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.Transfer.ID,
+				Valid: true,
+			},
+		})
+		return err
+	})
+
+	return result, err
+}
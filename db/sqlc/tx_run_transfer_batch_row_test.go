@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTransferBatchRowTx(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createFundedAccount(t, 0)
+	batch := createRandomTransferBatch(t, account, []CreateTransferBatchRowParams{
+		{RowNumber: 1, ToAccountID: toAccount.ID, Amount: 30, Reference: "PAY-001"},
+	})
+
+	arg := RunTransferBatchRowTxParams{
+		RowID: batch.Rows[0].ID,
+	}
+
+	result, err := testStore.RunTransferBatchRowTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(70), result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(30), result.Transfer.ToAccount.Balance)
+	require.Equal(t, "PAY-001", result.Transfer.Transfer.ExternalReference.String)
+	require.Equal(t, TransferBatchRowStatusSucceeded, result.Row.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Row.TransferID.Int64)
+
+	// The same row is never transferred twice
+	_, err = testStore.RunTransferBatchRowTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrTransferBatchRowProcessed)
+
+	account, err = testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(70), account.Balance)
+}
+
+func TestRunTransferBatchRowTxInsufficientFunds(t *testing.T) {
+	account := createFundedAccount(t, 10)
+	toAccount := createFundedAccount(t, 0)
+	batch := createRandomTransferBatch(t, account, []CreateTransferBatchRowParams{
+		{RowNumber: 1, ToAccountID: toAccount.ID, Amount: 30},
+	})
+
+	_, err := testStore.RunTransferBatchRowTx(context.Background(), RunTransferBatchRowTxParams{
+		RowID: batch.Rows[0].ID,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// The row is left pending for the worker to record why it failed
+	rows, err := testStore.ListTransferBatchRows(context.Background(), batch.Batch.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, TransferBatchRowStatusPending, rows[0].Status)
+}
+
+func TestRunTransferBatchRowTxSpendLimit(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount := createFundedAccount(t, 0)
+	member := createRandomAccountMember(t, account, AccountMemberRoleSpender, pgtype.Int8{Int64: 20, Valid: true})
+
+	_, err := testStore.AcceptAccountMember(context.Background(), AcceptAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.NoError(t, err)
+
+	batch, err := testStore.CreateTransferBatchTx(context.Background(), CreateTransferBatchTxParams{
+		Owner:         member.Username,
+		FromAccountID: account.ID,
+		Rows: []CreateTransferBatchRowParams{
+			{RowNumber: 1, ToAccountID: toAccount.ID, Amount: 30},
+		},
+		AfterCreate: func(batch TransferBatch) error {
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	// Each row is limited like a single transfer of the member
+	_, err = testStore.RunTransferBatchRowTx(context.Background(), RunTransferBatchRowTxParams{
+		RowID: batch.Rows[0].ID,
+	})
+	require.ErrorIs(t, err, ErrSpendLimitExceeded)
+
+	account, err = testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account.Balance)
+}
+
+func TestCompleteTransferBatch(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	toAccount1 := createFundedAccount(t, 0)
+	toAccount2 := createFundedAccount(t, 0)
+	batch := createRandomTransferBatch(t, account, []CreateTransferBatchRowParams{
+		{RowNumber: 1, ToAccountID: toAccount1.ID, Amount: 40},
+		{RowNumber: 2, ToAccountID: toAccount2.ID, Amount: 500},
+	})
+
+	_, err := testStore.RunTransferBatchRowTx(context.Background(), RunTransferBatchRowTxParams{
+		RowID: batch.Rows[0].ID,
+	})
+	require.NoError(t, err)
+
+	failedRow, err := testStore.FinishTransferBatchRow(context.Background(), FinishTransferBatchRowParams{
+		ID:     batch.Rows[1].ID,
+		Status: TransferBatchRowStatusFailed,
+		Error: pgtype.Text{
+			String: ErrInsufficientFunds.Error(),
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchRowStatusFailed, failedRow.Status)
+
+	// A finished row can not be finished again
+	_, err = testStore.FinishTransferBatchRow(context.Background(), FinishTransferBatchRowParams{
+		ID:     batch.Rows[1].ID,
+		Status: TransferBatchRowStatusFailed,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	completed, err := testStore.CompleteTransferBatch(context.Background(), batch.Batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchStatusCompleted, completed.Status)
+	require.Equal(t, int32(1), completed.SucceededCount)
+	require.Equal(t, int32(1), completed.FailedCount)
+	require.Equal(t, int64(40), completed.SucceededAmount)
+	require.Equal(t, int64(540), completed.TotalAmount)
+	require.True(t, completed.CompletedAt.Valid)
+}
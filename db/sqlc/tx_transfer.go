@@ -1,12 +1,65 @@
 package db
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TransferDetails contains the optional details supplied by the client along with a transfer
+// They are omitted from JSON when empty, so the request hash of a transfer without details does not depend on them
+type TransferDetails struct {
+	Memo              string            `json:"memo,omitempty"`
+	ExternalReference string            `json:"external_reference,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	// Username or email of the recipient of a payment, set by the server so the account of the recipient stays private
+	Recipient string `json:"recipient,omitempty"`
+}
+
+// columns converts the details into the nullable columns shared by transfers and holds
+func (details TransferDetails) columns() (memo pgtype.Text, externalReference pgtype.Text, recipient pgtype.Text, metadata json.RawMessage, err error) {
+	memo = pgtype.Text{
+		String: details.Memo,
+		Valid:  details.Memo != "",
+	}
+	externalReference = pgtype.Text{
+		String: details.ExternalReference,
+		Valid:  details.ExternalReference != "",
+	}
+	recipient = pgtype.Text{
+		String: details.Recipient,
+		Valid:  details.Recipient != "",
+	}
+
+	if len(details.Metadata) > 0 {
+		metadata, err = json.Marshal(details.Metadata)
+	}
+
+	return
+}
 
 // TransferTxParams contains the input parameters of the transfer transaction
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	Amount        int64 `json:"amount"`
+	TransferDetails
+	// Optional: a retried request with the same key returns the stored result instead of transferring again
+	Idempotency *IdempotencyParams `json:"-"`
+	// Optional: the fraud screening decision which allowed the transfer, recorded along with it
+	RiskDecision *CreateRiskDecisionParams `json:"-"`
+}
+
+// CrossCurrencyTransferTxParams contains the input parameters of a transfer between accounts of different currencies
+// The from account is debited Amount in its own currency and the to account is credited ToAmount in its own currency
+type CrossCurrencyTransferTxParams struct {
+	TransferTxParams
+	ToAmount int64 `json:"to_amount"`
+	// Fixed-point rate, scaled by fx.RateScale
+	ExchangeRate int64  `json:"exchange_rate"`
+	QuoteID      string `json:"quote_id"`
 }
 
 // TransferTxResult is the result of the transfer transaction
@@ -16,6 +69,11 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// Entries of the system accounts which keep each currency balanced, e.g. the FX legs of a cross-currency transfer
+	// They are internal to the bank, so they are neither returned to clients nor replayed for idempotent requests
+	SystemEntries []Entry `json:"-"`
+	// Fees charged to the from account for the transfer, each one is a separate transfer to the fee revenue account
+	Fees []FeeLine `json:"fees"`
 }
 
 // To get the transaction name from the input context of the TransferTx() function
@@ -25,55 +83,97 @@ type TransferTxResult struct {
 // TransferTx performs a money transfer from one account to the other
 // It creates a transfer record, add account entries, update account's balance within a single database transaction\
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
+	return store.transferTx(ctx, arg, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      arg.Amount,
+	}, false)
+}
+
+// CrossCurrencyTransferTx performs a money transfer between accounts of different currencies
+// The converted amount, the exchange rate and the quote ID are recorded on the transfer
+func (store *SQLStore) CrossCurrencyTransferTx(ctx context.Context, arg CrossCurrencyTransferTxParams) (TransferTxResult, error) {
+	return store.transferTx(ctx, arg.TransferTxParams, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      arg.ToAmount,
+		ExchangeRate: pgtype.Int8{
+			Int64: arg.ExchangeRate,
+			Valid: true,
+		},
+		QuoteID: pgtype.Text{
+			String: arg.QuoteID,
+			Valid:  true,
+		},
+	}, false)
+}
+
+// transferTx moves transfer.Amount out of the from account and transfer.ToAmount into the to account
+// Only the client request is hashed for idempotency, so a retry replays the stored result even if the quote changed since
+func (store *SQLStore) transferTx(ctx context.Context, arg TransferTxParams, transfer CreateTransferParams, internal bool) (TransferTxResult, error) {
 	var result TransferTxResult
+	var err error
 
-	err := store.execTx(ctx, func(q *Queries) error {
+	transfer.Memo, transfer.ExternalReference, transfer.Recipient, transfer.Metadata, err = arg.columns()
+	if err != nil {
+		return result, err
+	}
+
+	err = store.execTx(ctx, func(q *Queries) error {
 		var err error
 
 		// Get value in context with the key is txKey
 		// txName := ctx.Value(txKey)
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
-		if err != nil {
-			return err
+		if arg.Idempotency != nil {
+			hash, err := requestHash(arg)
+			if err != nil {
+				return err
+			}
+
+			replayed, err := claimIdempotencyKey(ctx, q, arg.Idempotency, hash, &result)
+			if err != nil {
+				return err
+			}
+
+			// A concurrent attempt of the same request may have been held for review rather than transferred
+			if replayed && result.Transfer.ID == 0 {
+				return ErrIdempotencyKeyConflict
+			}
+			if replayed {
+				return nil
+			}
 		}
 
-		result.FromEntry, result.ToEntry, err = createEntries(ctx, q, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+		result, err = postTransfer(ctx, q, transfer, internal)
 		if err != nil {
 			return err
 		}
 
-		// Get account => update its balance
-
-		/*
-			//! Use 2 queries for getting and updating is not too good
-			//! => Use only 1 query for changing the account balance
-				account1, err := q.GetAccountForUpdate(ctx, arg.FromAccountId)
-				if err != nil {
-					return err
-				}
-
-				result.FromAccount, err = q.UpdateAccount(ctx, UpdateAccountParams{
-					ID:      arg.FromAccountId,
-					Balance: account1.Balance - arg.Amount,
-				})
-				if err != nil {
-					return err
-				}
-		*/
-
-		// Avoid DB deadlock : query order matter
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+		if !internal {
+			err = chargeTransferFee(ctx, q, &result)
+			if err != nil {
+				return err
+			}
 		}
-		if err != nil {
-			return err
+
+		if arg.RiskDecision != nil {
+			decision := *arg.RiskDecision
+			decision.TransferID = pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			}
+
+			_, err = q.CreateRiskDecision(ctx, decision)
+			if err != nil {
+				return err
+			}
+		}
+
+		if arg.Idempotency != nil {
+			return saveIdempotentResponse(ctx, q, arg.Idempotency, result)
 		}
 
 		return nil
@@ -82,50 +182,166 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 	return result, err
 }
 
-func createEntries(
-	ctx context.Context,
-	q *Queries,
-	fromAccountID int64,
-	toAccountID int64,
-	amount int64,
-) (fromEntry, toEntry Entry, err error) {
-	fromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-		AccountID: fromAccountID,
-		Amount:    -amount,
-	})
+// postTransfer records the transfer with balanced entries and updates the balances of the accounts
+// Customer transfers can not involve system accounts, internal ones (deposits, withdrawals...) can
+func postTransfer(ctx context.Context, q *Queries, transfer CreateTransferParams, internal bool) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
+
+	result.Transfer, err = q.CreateTransfer(ctx, transfer)
 	if err != nil {
-		return
+		return result, err
 	}
 
-	toEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-		AccountID: toAccountID,
-		Amount:    amount,
-	})
+	legs := []ledgerLeg{
+		{AccountID: transfer.FromAccountID, Amount: -transfer.Amount},
+		{AccountID: transfer.ToAccountID, Amount: transfer.ToAmount},
+	}
 
-	return
+	// The currencies differ, the FX position of the bank balances each of them
+	if transfer.ExchangeRate.Valid {
+		fxLegs, err := fxLegs(ctx, q, transfer)
+		if err != nil {
+			return result, err
+		}
+
+		legs = append(legs, fxLegs...)
+	}
+
+	accounts, entries, err := postEntries(ctx, q, result.Transfer.ID, legs)
+	if err != nil {
+		return result, err
+	}
+
+	result.FromAccount, result.ToAccount = accounts[0], accounts[1]
+	result.FromEntry, result.ToEntry = entries[0], entries[1]
+	result.SystemEntries = entries[2:]
+
+	if !internal && (IsSystemAccount(result.FromAccount) || IsSystemAccount(result.ToAccount)) {
+		return result, ErrSystemAccount
+	}
+
+	// Frozen and closed accounts can neither send nor receive money
+	for _, account := range accounts {
+		if account.Status != AccountStatusActive {
+			return result, ErrAccountNotActive
+		}
+	}
+
+	// The balance is read from the locked row which is updated above
+	// so concurrent transfers can not overdraw the account, the whole transaction is rolled back instead
+	// Held funds are reserved, they can only be spent by capturing their hold
+	// System accounts are the other side of the customer balances, e.g. the cash account is negative once cash is deposited
+	if result.FromAccount.AvailableBalance < 0 && !IsSystemAccount(result.FromAccount) {
+		return result, ErrInsufficientFunds
+	}
+
+	// Internal transfers are made by the bank, only the customer ones are limited
+	if !internal {
+		err = checkTransferLimits(ctx, q, result.FromAccount, transfer.Amount)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
 }
 
-func addMoney(
-	ctx context.Context,
-	q *Queries,
-	accountID1 int64,
-	amount1 int64,
-	accountID2 int64,
-	amount2 int64,
-) (account1, account2 Account, err error) {
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID1,
-		Amount: amount1,
-	})
+// fxLegs moves the amounts of a cross-currency transfer through the FX accounts of both currencies
+func fxLegs(ctx context.Context, q *Queries, transfer CreateTransferParams) ([]ledgerLeg, error) {
+	fromAccount, err := q.GetAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	toAccount, err := q.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromFX, err := getSystemAccount(ctx, q, SystemFXOwner, fromAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	toFX, err := getSystemAccount(ctx, q, SystemFXOwner, toAccount.Currency)
 	if err != nil {
-		// the same as: return account1, account2, err
-		return
+		return nil, err
 	}
 
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID2,
-		Amount: amount2,
+	return []ledgerLeg{
+		{AccountID: fromFX.ID, Amount: transfer.Amount},
+		{AccountID: toFX.ID, Amount: -transfer.ToAmount},
+	}, nil
+}
+
+// ledgerLeg is the change of an account balance within a transfer
+type ledgerLeg struct {
+	AccountID int64
+	Amount    int64
+}
+
+// postEntries applies the legs to the account balances and records them as entries linked to the transfer
+// The accounts and entries are returned in the order of the legs
+func postEntries(ctx context.Context, q *Queries, transferID int64, legs []ledgerLeg) ([]Account, []Entry, error) {
+	accounts := make([]Account, len(legs))
+	entries := make([]Entry, len(legs))
+
+	// Avoid DB deadlock : query order matter
+	// Every transaction locks the accounts by ascending ID, so two of them can not wait for each other
+	order := make([]int, len(legs))
+	for index := range order {
+		order[index] = index
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return legs[order[i]].AccountID < legs[order[j]].AccountID
 	})
 
-	return
+	/*
+		//! Use 2 queries for getting and updating is not too good
+		//! => Use only 1 query for changing the account balance
+			account1, err := q.GetAccountForUpdate(ctx, arg.FromAccountId)
+			if err != nil {
+				return err
+			}
+
+			result.FromAccount, err = q.UpdateAccount(ctx, UpdateAccountParams{
+				ID:      arg.FromAccountId,
+				Balance: account1.Balance - arg.Amount,
+			})
+			if err != nil {
+				return err
+			}
+	*/
+
+	var err error
+	for _, index := range order {
+		accounts[index], err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     legs[index].AccountID,
+			Amount: legs[index].Amount,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Written after the balances are updated, while the account rows are still locked,
+	// so the entry IDs of an account follow the order of its balance changes
+	transfer := pgtype.Int8{
+		Int64: transferID,
+		Valid: true,
+	}
+	for _, index := range order {
+		entries[index], err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:    legs[index].AccountID,
+			Amount:       legs[index].Amount,
+			TransferID:   transfer,
+			BalanceAfter: accounts[index].Balance,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return accounts, entries, nil
 }
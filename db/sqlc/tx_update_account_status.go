@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+)
+
+// UpdateAccountStatusTxParams contains the input parameters of the update account status transaction
+type UpdateAccountStatusTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+	// Username of the user who changes the status
+	ChangedBy string `json:"changed_by"`
+}
+
+// UpdateAccountStatusTxResult is the result of the update account status transaction
+type UpdateAccountStatusTxResult struct {
+	Account      Account             `json:"account"`
+	StatusChange AccountStatusChange `json:"status_change"`
+}
+
+// UpdateAccountStatusTx moves an account to another status and records who did it and why
+// Only an account with a zero balance can be closed
+func (store *SQLStore) UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (UpdateAccountStatusTxResult, error) {
+	var result UpdateAccountStatusTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// Locked, so a concurrent transfer can not change the balance before the account is closed
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if IsSystemAccount(account) || !canChangeStatus(account.Status, arg.Status) {
+			return ErrInvalidStatusChange
+		}
+
+		if arg.Status == AccountStatusClosed && account.Balance != 0 {
+			return ErrNonZeroBalance
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     arg.AccountID,
+			Status: arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.StatusChange, err = q.CreateAccountStatusChange(ctx, CreateAccountStatusChangeParams{
+			AccountID:  arg.AccountID,
+			FromStatus: account.Status,
+			ToStatus:   arg.Status,
+			Reason:     arg.Reason,
+			ChangedBy:  arg.ChangedBy,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateAccountStatusTx(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	banker := createRandomUser(t)
+
+	result, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusFrozen,
+		Reason:    "suspicious activity",
+		ChangedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, result.Account.Status)
+	require.Equal(t, AccountStatusActive, result.StatusChange.FromStatus)
+	require.Equal(t, AccountStatusFrozen, result.StatusChange.ToStatus)
+	require.Equal(t, banker.Username, result.StatusChange.ChangedBy)
+
+	// Frozen accounts can neither send nor receive money
+	other := createFundedAccount(t, 100)
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: other.ID,
+		ToAccountID:   account.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	// A frozen account must be unfrozen before it is closed
+	_, err = testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusClosed,
+		Reason:    "closed by the owner",
+		ChangedBy: account.Owner,
+	})
+	require.ErrorIs(t, err, ErrInvalidStatusChange)
+
+	result, err = testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusActive,
+		Reason:    "verified by phone",
+		ChangedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, result.Account.Status)
+}
+
+func TestCloseAccountNonZeroBalance(t *testing.T) {
+	account := createFundedAccount(t, 100)
+
+	_, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusClosed,
+		Reason:    "closed by the owner",
+		ChangedBy: account.Owner,
+	})
+	require.ErrorIs(t, err, ErrNonZeroBalance)
+
+	account = createFundedAccount(t, 0)
+	result, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusClosed,
+		Reason:    "closed by the owner",
+		ChangedBy: account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+}
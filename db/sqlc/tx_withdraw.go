@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// WithdrawTxParams contains the input parameters of the withdraw transaction
+type WithdrawTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+	// One of the util reason codes
+	ReasonCode string `json:"reason_code"`
+	// Optional
+	ExternalReference string `json:"external_reference"`
+}
+
+// WithdrawTxResult is the result of the withdraw transaction
+type WithdrawTxResult struct {
+	Transfer Transfer `json:"transfer"`
+	Account  Account  `json:"account"`
+	Entry    Entry    `json:"entry"`
+}
+
+// WithdrawTx debits an account with cash taken out of the bank
+// The money goes to the cash account of the same currency, the account can not be overdrawn
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error) {
+	var result WithdrawTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if IsSystemAccount(account) {
+			return ErrSystemAccount
+		}
+
+		cash, err := getSystemAccount(ctx, q, SystemCashOwner, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		posted, err := postTransfer(ctx, q, CreateTransferParams{
+			FromAccountID: account.ID,
+			ToAccountID:   cash.ID,
+			Amount:        arg.Amount,
+			ToAmount:      arg.Amount,
+			ReasonCode: pgtype.Text{
+				String: arg.ReasonCode,
+				Valid:  true,
+			},
+			ExternalReference: pgtype.Text{
+				String: arg.ExternalReference,
+				Valid:  arg.ExternalReference != "",
+			},
+		}, true)
+		if err != nil {
+			return err
+		}
+
+		result.Transfer = posted.Transfer
+		result.Account = posted.FromAccount
+		result.Entry = posted.FromEntry
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithdrawTx(t *testing.T) {
+	account := createFundedAccount(t, 100)
+	amount := int64(60)
+
+	result, err := testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:         account.ID,
+		Amount:            amount,
+		ReasonCode:        util.CashReason,
+		ExternalReference: util.RandomString(12),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance-amount, result.Account.Balance)
+	require.Equal(t, -amount, result.Entry.Amount)
+	require.Equal(t, result.Account.Balance, result.Entry.BalanceAfter)
+	require.Equal(t, util.CashReason, result.Transfer.ReasonCode.String)
+	require.True(t, result.Transfer.ExternalReference.Valid)
+
+	cash, err := testStore.GetAccount(context.Background(), result.Transfer.ToAccountID)
+	require.NoError(t, err)
+	require.Equal(t, SystemCashOwner, cash.Owner)
+	require.Equal(t, account.Currency, cash.Currency)
+
+	// The remaining balance can not cover a second withdrawal
+	_, err = testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
@@ -23,19 +23,36 @@ CREATE TABLE "verify_emails" (
   "expired_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
 );
 
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  "numeric_code" integer UNIQUE NOT NULL,
+  "exponent" integer NOT NULL,
+  "symbol" varchar NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
 CREATE TABLE "accounts" (
   "id" bigserial PRIMARY KEY,
   "owner" varchar NOT NULL,
   "balance" bigint NOT NULL,
   "currency" varchar NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "status" varchar NOT NULL DEFAULT 'active',
+  "held_amount" bigint NOT NULL DEFAULT 0,
+  "available_balance" bigint GENERATED ALWAYS AS ("balance" - "held_amount") STORED,
+  "tier" varchar NOT NULL DEFAULT 'standard',
+  "product" varchar NOT NULL DEFAULT 'checking'
 );
 
 CREATE TABLE "entries" (
   "id" bigserial PRIMARY KEY,
   "account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "transfer_id" bigint,
+  "balance_after" bigint NOT NULL
 );
 
 CREATE TABLE "transfers" (
@@ -43,7 +60,16 @@ CREATE TABLE "transfers" (
   "from_account_id" bigint NOT NULL,
   "to_account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "to_amount" bigint NOT NULL,
+  "exchange_rate" bigint,
+  "quote_id" varchar,
+  "reason_code" varchar,
+  "external_reference" varchar,
+  "reversal_of_transfer_id" bigint,
+  "memo" varchar,
+  "metadata" jsonb,
+  "recipient" varchar
 );
 
 CREATE TABLE "sessions" (
@@ -57,30 +83,505 @@ CREATE TABLE "sessions" (
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
+CREATE TABLE "idempotency_keys" (
+  "username" varchar NOT NULL,
+  "key" varchar NOT NULL,
+  "request_hash" varchar NOT NULL,
+  "response" jsonb,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL,
+  PRIMARY KEY ("username", "key")
+);
+
+CREATE TABLE "exchange_rates" (
+  "from_currency" varchar NOT NULL,
+  "to_currency" varchar NOT NULL,
+  "rate" bigint NOT NULL,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("from_currency", "to_currency")
+);
+
+CREATE TABLE "reconciliation_reports" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "balance" bigint NOT NULL,
+  "entries_total" bigint NOT NULL,
+  "difference" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "account_status_changes" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "from_status" varchar NOT NULL,
+  "to_status" varchar NOT NULL,
+  "reason" varchar NOT NULL,
+  "changed_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "memo" varchar,
+  "external_reference" varchar,
+  "metadata" jsonb,
+  "recipient" varchar
+);
+
+CREATE TABLE "scheduled_transfers" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "recurrence" varchar,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "next_run_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "scheduled_transfer_runs" (
+  "id" bigserial PRIMARY KEY,
+  "scheduled_transfer_id" bigint NOT NULL,
+  "scheduled_at" timestamptz NOT NULL,
+  "status" varchar NOT NULL,
+  "transfer_id" bigint,
+  "error" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_limits" (
+  "role" varchar NOT NULL,
+  "tier" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "max_amount" bigint NOT NULL,
+  "max_daily_amount" bigint NOT NULL,
+  "max_daily_count" integer NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "tier", "currency")
+);
+
+CREATE TABLE "risk_decisions" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "decision" varchar NOT NULL,
+  "rules" varchar[] NOT NULL,
+  "transfer_id" bigint,
+  "hold_id" bigint,
+  "resolution" varchar,
+  "reviewed_by" varchar,
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "account_products" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "amount" bigint NOT NULL,
+  "remainder" bigint NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "fee_schedules" (
+  "product" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "monthly_fee" bigint NOT NULL DEFAULT 0,
+  "transfer_fee" bigint NOT NULL DEFAULT 0,
+  "minimum_balance" bigint NOT NULL DEFAULT 0,
+  "below_minimum_fee" bigint NOT NULL DEFAULT 0,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("product", "currency")
+);
+
+CREATE TABLE "fee_charges" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "kind" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "period" date,
+  "transfer_id" bigint,
+  "charged_transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "row_count" integer NOT NULL,
+  "total_amount" bigint NOT NULL,
+  "succeeded_count" integer NOT NULL DEFAULT 0,
+  "failed_count" integer NOT NULL DEFAULT 0,
+  "succeeded_amount" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+CREATE TABLE "transfer_batch_rows" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "row_number" integer NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "reference" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "error" varchar,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "to_account_id" bigint,
+  "to_username" varchar,
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "confirmation_code" varchar,
+  "confirmation_expires_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "account_members" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "spend_limit" bigint,
+  "status" varchar NOT NULL DEFAULT 'invited',
+  "invited_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "accepted_at" timestamptz
+);
+
 CREATE INDEX ON "accounts" ("owner");
 
 CREATE UNIQUE INDEX ON "accounts" ("owner", "currency");
 
 CREATE INDEX ON "entries" ("account_id");
 
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
 CREATE INDEX ON "transfers" ("from_account_id");
 
 CREATE INDEX ON "transfers" ("to_account_id");
 
 CREATE INDEX ON "transfers" ("from_account_id", "to_account_id");
 
+CREATE INDEX ON "transfers" ("reversal_of_transfer_id");
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+CREATE INDEX ON "transfers" ("external_reference");
+
+CREATE INDEX ON "sessions" ("username", "created_at");
+
+CREATE INDEX ON "reconciliation_reports" ("account_id");
+
+CREATE INDEX ON "account_status_changes" ("account_id");
+
+CREATE INDEX ON "holds" ("account_id");
+
+CREATE INDEX ON "holds" ("status", "expires_at");
+
+CREATE INDEX ON "scheduled_transfers" ("owner");
+
+CREATE INDEX ON "scheduled_transfers" ("status", "next_run_at");
+
+CREATE UNIQUE INDEX ON "scheduled_transfer_runs" ("scheduled_transfer_id", "scheduled_at");
+
+CREATE INDEX ON "risk_decisions" ("from_account_id");
+
+CREATE INDEX ON "risk_decisions" ("decision", "resolution");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("transfer_id");
+
+CREATE UNIQUE INDEX ON "fee_charges" ("account_id", "kind", "period");
+
+CREATE INDEX ON "fee_charges" ("charged_transfer_id");
+
+CREATE INDEX ON "transfer_batches" ("owner");
+
+CREATE UNIQUE INDEX ON "transfer_batch_rows" ("batch_id", "row_number");
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "nickname");
+
+CREATE UNIQUE INDEX ON "account_members" ("account_id", "username");
+
+CREATE INDEX ON "account_members" ("username");
+
+COMMENT ON COLUMN "users"."role" IS 'depositor, banker or system, system users own the internal accounts of the ledger';
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT ON COLUMN "currencies"."exponent" IS 'number of digits after the decimal separator of the minor unit';
+
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen or closed, only active accounts can send or receive money';
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'sum of the pending holds of the account';
+
+COMMENT ON COLUMN "accounts"."available_balance" IS 'balance which can still be spent';
+
+COMMENT ON COLUMN "accounts"."tier" IS 'standard or premium, selects the transfer limits of the account';
+
+COMMENT ON COLUMN "accounts"."product" IS 'account product, selects the interest rate of the account';
+
 COMMENT ON COLUMN "entries"."amount" IS 'can be negative or positive';
 
+COMMENT ON COLUMN "entries"."transfer_id" IS 'null for entries which are not part of a transfer';
+
+COMMENT ON COLUMN "entries"."balance_after" IS 'balance of the account right after this entry';
+
 COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
 
+COMMENT ON COLUMN "transfers"."to_amount" IS 'credited amount in the currency of the to account';
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'fixed-point, scaled by 10^8, null for same-currency transfers';
+
+COMMENT ON COLUMN "transfers"."reason_code" IS 'why a banker deposited or withdrew the money, null for customer transfers';
+
+COMMENT ON COLUMN "transfers"."external_reference" IS 'reference of the movement in an external system, e.g. a teller receipt or the invoice paid by a customer';
+
+COMMENT ON COLUMN "transfers"."reversal_of_transfer_id" IS 'transfer refunded in part or in full by this one, which keeps its exchange rate';
+
+COMMENT ON COLUMN "transfers"."memo" IS 'free text written by the customer who sent the transfer';
+
+COMMENT ON COLUMN "transfers"."metadata" IS 'string map supplied by the client, null when empty';
+
+COMMENT ON COLUMN "transfers"."recipient" IS 'username or email paid by the sender of a payment, the account of the recipient is only shown to its own side when set';
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'fixed-point, scaled by 10^8';
+
+COMMENT ON COLUMN "reconciliation_reports"."difference" IS 'balance minus entries_total, 0 once an earlier discrepancy is resolved';
+
+COMMENT ON COLUMN "holds"."amount" IS 'must be positive';
+
+COMMENT ON COLUMN "holds"."status" IS 'pending, captured, released or expired';
+
+COMMENT ON COLUMN "holds"."transfer_id" IS 'transfer which settled the hold once captured';
+
+COMMENT ON COLUMN "holds"."memo" IS 'details of the transfer made when the hold is captured';
+
+COMMENT ON COLUMN "holds"."recipient" IS 'recipient of the payment made when the hold is captured';
+
+COMMENT ON COLUMN "scheduled_transfers"."amount" IS 'must be positive';
+
+COMMENT ON COLUMN "scheduled_transfers"."recurrence" IS 'cron expression, null for a one-off transfer';
+
+COMMENT ON COLUMN "scheduled_transfers"."status" IS 'active, dispatched, completed, failed or cancelled';
+
+COMMENT ON COLUMN "scheduled_transfers"."next_run_at" IS 'null unless active';
+
+COMMENT ON COLUMN "scheduled_transfer_runs"."status" IS 'succeeded or failed';
+
+COMMENT ON COLUMN "scheduled_transfer_runs"."error" IS 'why the transfer was rejected, null when it succeeded';
+
+COMMENT ON COLUMN "transfer_limits"."role" IS 'role of the owner of the from account';
+
+COMMENT ON COLUMN "transfer_limits"."max_amount" IS 'largest single transfer, in minor units';
+
+COMMENT ON COLUMN "transfer_limits"."max_daily_amount" IS 'largest total sent per UTC day, in minor units';
+
+COMMENT ON COLUMN "transfer_limits"."max_daily_count" IS 'most transfers sent per UTC day';
+
+COMMENT ON COLUMN "risk_decisions"."username" IS 'user who requested the transfer';
+
+COMMENT ON COLUMN "risk_decisions"."decision" IS 'allow, review or block';
+
+COMMENT ON COLUMN "risk_decisions"."rules" IS 'names of the fraud rules which triggered';
+
+COMMENT ON COLUMN "risk_decisions"."transfer_id" IS 'transfer made once allowed or approved';
+
+COMMENT ON COLUMN "risk_decisions"."hold_id" IS 'hold on the funds of a transfer waiting for a review';
+
+COMMENT ON COLUMN "risk_decisions"."resolution" IS 'approved or rejected by a banker, null until a review is resolved';
+
+COMMENT ON COLUMN "account_products"."annual_rate_bps" IS 'annual interest rate in basis points, 200 is 2%';
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'balance at the end of the accrual date, in minor units';
+
+COMMENT ON COLUMN "interest_accruals"."annual_rate_bps" IS 'rate of the product on the accrual date';
+
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'interest earned on the accrual date, in minor units';
+
+COMMENT ON COLUMN "interest_accruals"."remainder" IS 'fraction of a minor unit carried to the next accrual, in 1/3650000 of a minor unit';
+
+COMMENT ON COLUMN "interest_accruals"."transfer_id" IS 'interest transfer which paid the accrual, null until the month-end posting';
+
+COMMENT ON COLUMN "fee_schedules"."monthly_fee" IS 'maintenance fee charged every month, in minor units';
+
+COMMENT ON COLUMN "fee_schedules"."transfer_fee" IS 'fee charged with every transfer sent, in minor units';
+
+COMMENT ON COLUMN "fee_schedules"."minimum_balance" IS 'balance to keep at the end of the month to avoid the below minimum fee, in minor units';
+
+COMMENT ON COLUMN "fee_schedules"."below_minimum_fee" IS 'fee charged when the month ends below the minimum balance, in minor units';
+
+COMMENT ON COLUMN "fee_charges"."kind" IS 'monthly, transfer or below_minimum';
+
+COMMENT ON COLUMN "fee_charges"."amount" IS 'amount actually charged, less than the fee when the account could not pay all of it';
+
+COMMENT ON COLUMN "fee_charges"."period" IS 'first day of the month of a monthly or below minimum fee, null for transfer fees';
+
+COMMENT ON COLUMN "fee_charges"."transfer_id" IS 'transfer to the fee revenue account, null when nothing could be charged';
+
+COMMENT ON COLUMN "fee_charges"."charged_transfer_id" IS 'transfer which incurred a transfer fee';
+
+COMMENT ON COLUMN "transfer_batches"."status" IS 'pending until every row is processed, then completed';
+
+COMMENT ON COLUMN "transfer_batches"."total_amount" IS 'sum of the amounts of the rows';
+
+COMMENT ON COLUMN "transfer_batches"."succeeded_count" IS 'summary of the rows, set once the batch is completed';
+
+COMMENT ON COLUMN "transfer_batch_rows"."row_number" IS 'position of the row in the uploaded batch, from 1';
+
+COMMENT ON COLUMN "transfer_batch_rows"."reference" IS 'recorded as the external reference of the transfer, empty for none';
+
+COMMENT ON COLUMN "transfer_batch_rows"."status" IS 'pending, succeeded or failed';
+
+COMMENT ON COLUMN "transfer_batch_rows"."error" IS 'why the transfer of a failed row was rejected';
+
+COMMENT ON COLUMN "payees"."to_account_id" IS 'set when the payee is an account, null when it is a user';
+
+COMMENT ON COLUMN "payees"."to_username" IS 'set when the payee is a user paid into its account in the currency, null when it is an account';
+
+COMMENT ON COLUMN "payees"."status" IS 'pending until the confirmation code is entered, then active';
+
+COMMENT ON COLUMN "payees"."confirmation_code" IS 'code emailed to the owner, null once confirmed';
+
+COMMENT ON COLUMN "account_members"."role" IS 'owner, co_owner, viewer or spender';
+
+COMMENT ON COLUMN "account_members"."spend_limit" IS 'largest amount a spender can send in one transfer, null for the other roles';
+
+COMMENT ON COLUMN "account_members"."status" IS 'invited until the user accepts, then active';
+
 ALTER TABLE "verify_emails" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
 
 ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
 
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
 ALTER TABLE "entries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
 
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
 ALTER TABLE "transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
 
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversal_of_transfer_id") REFERENCES "transfers" ("id");
+
 ALTER TABLE "sessions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "exchange_rates" ADD FOREIGN KEY ("from_currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "exchange_rates" ADD FOREIGN KEY ("to_currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "exchange_rates" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "reconciliation_reports" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_status_changes" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_status_changes" ADD FOREIGN KEY ("changed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("hold_id") REFERENCES "holds" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "fee_charges" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fee_charges" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "fee_charges" ADD FOREIGN KEY ("charged_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_rows" ADD FOREIGN KEY ("batch_id") REFERENCES "transfer_batches" ("id");
+
+ALTER TABLE "transfer_batch_rows" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_rows" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
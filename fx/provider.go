@@ -0,0 +1,98 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+)
+
+// RateProvider gives exchange rate quotes between currencies
+type RateProvider interface {
+	// GetQuote returns the current rate for converting fromCurrency to toCurrency
+	GetQuote(ctx context.Context, fromCurrency, toCurrency string) (Quote, error)
+}
+
+type currencyPair struct {
+	from string
+	to   string
+}
+
+// StaticRateProvider serves rates kept in memory, it is meant for tests and local setups
+type StaticRateProvider struct {
+	mutex sync.RWMutex
+	rates map[currencyPair]int64
+}
+
+func NewStaticRateProvider() *StaticRateProvider {
+	return &StaticRateProvider{
+		rates: make(map[currencyPair]int64),
+	}
+}
+
+// SetRate sets the fixed-point rate for converting fromCurrency to toCurrency
+func (provider *StaticRateProvider) SetRate(fromCurrency, toCurrency string, rate int64) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	provider.rates[currencyPair{from: fromCurrency, to: toCurrency}] = rate
+}
+
+func (provider *StaticRateProvider) GetQuote(ctx context.Context, fromCurrency, toCurrency string) (Quote, error) {
+	if fromCurrency == toCurrency {
+		return newQuote(fromCurrency, toCurrency, RateScale), nil
+	}
+
+	provider.mutex.RLock()
+	rate, ok := provider.rates[currencyPair{from: fromCurrency, to: toCurrency}]
+	provider.mutex.RUnlock()
+	if !ok {
+		return Quote{}, ErrRateNotFound
+	}
+
+	return newQuote(fromCurrency, toCurrency, rate), nil
+}
+
+// StoreRateProvider serves the rates of the exchange_rates table which bankers keep up to date
+type StoreRateProvider struct {
+	store db.Store
+}
+
+func NewStoreRateProvider(store db.Store) *StoreRateProvider {
+	return &StoreRateProvider{
+		store: store,
+	}
+}
+
+func (provider *StoreRateProvider) GetQuote(ctx context.Context, fromCurrency, toCurrency string) (Quote, error) {
+	if fromCurrency == toCurrency {
+		return newQuote(fromCurrency, toCurrency, RateScale), nil
+	}
+
+	rate, err := provider.store.GetExchangeRate(ctx, db.GetExchangeRateParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return Quote{}, ErrRateNotFound
+		}
+
+		return Quote{}, err
+	}
+
+	return newQuote(fromCurrency, toCurrency, rate.Rate), nil
+}
+
+func newQuote(fromCurrency, toCurrency string, rate int64) Quote {
+	return Quote{
+		ID:           uuid.New().String(),
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         rate,
+		CreatedAt:    time.Now(),
+	}
+}
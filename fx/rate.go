@@ -0,0 +1,89 @@
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/currency"
+)
+
+const (
+	// RateScale is the fixed-point scale of exchange rates, a rate of 1 is stored as RateScale
+	RateScale = 100_000_000
+	// rateDecimals is the number of decimal digits kept by RateScale
+	rateDecimals = 8
+)
+
+var (
+	ErrRateNotFound     = errors.New("exchange rate not found")
+	ErrInvalidRate      = errors.New("invalid exchange rate")
+	ErrAmountOverflow   = errors.New("converted amount overflows")
+	ErrCurrencyMismatch = errors.New("currencies do not match the quote")
+)
+
+// Quote is an exchange rate offered for converting money from one currency to the other
+type Quote struct {
+	ID           string    `json:"id"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	Rate         int64     `json:"rate"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Convert returns the amount in the minor units of the target currency
+// The rate is quoted between major units, so the exponents of both currencies are taken into account
+// The result is rounded down so the bank never credits more than it debits
+func (quote Quote) Convert(amount currency.Money, to currency.Currency) (currency.Money, error) {
+	if amount.Currency.Code != quote.FromCurrency || to.Code != quote.ToCurrency {
+		return currency.Money{}, ErrCurrencyMismatch
+	}
+
+	numerator := new(big.Int).Mul(big.NewInt(amount.Amount), big.NewInt(quote.Rate))
+	numerator.Mul(numerator, pow10(to.Exponent))
+
+	denominator := new(big.Int).Mul(big.NewInt(RateScale), pow10(amount.Currency.Exponent))
+
+	result := numerator.Quo(numerator, denominator)
+	if !result.IsInt64() {
+		return currency.Money{}, ErrAmountOverflow
+	}
+
+	return currency.NewMoney(result.Int64(), to), nil
+}
+
+func pow10(exponent int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// ParseRate converts a decimal string like "0.92" to a fixed-point rate
+func ParseRate(value string) (int64, error) {
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" || len(fraction) > rateDecimals {
+		return 0, ErrInvalidRate
+	}
+
+	fraction += strings.Repeat("0", rateDecimals-len(fraction))
+	for _, digit := range whole + fraction {
+		if digit < '0' || digit > '9' {
+			return 0, ErrInvalidRate
+		}
+	}
+
+	rate, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || rate <= 0 {
+		return 0, ErrInvalidRate
+	}
+
+	return rate, nil
+}
+
+// FormatRate converts a fixed-point rate to its shortest decimal string
+func FormatRate(rate int64) string {
+	value := fmt.Sprintf("%d.%0*d", rate/RateScale, rateDecimals, rate%RateScale)
+	value = strings.TrimRight(value, "0")
+	return strings.TrimSuffix(value, ".")
+}
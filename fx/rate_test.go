@@ -0,0 +1,126 @@
+package fx
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/hoangtk0100/simple-bank/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteConvert(t *testing.T) {
+	usd := currency.Currency{Code: "USD", Exponent: 2}
+	eur := currency.Currency{Code: "EUR", Exponent: 2}
+	jpy := currency.Currency{Code: "JPY", Exponent: 0}
+
+	testCases := []struct {
+		name   string
+		quote  Quote
+		amount currency.Money
+		to     currency.Currency
+		result int64
+		err    error
+	}{
+		{
+			name:   "RoundDown",
+			quote:  Quote{FromCurrency: "USD", ToCurrency: "EUR", Rate: 92_345_678},
+			amount: currency.NewMoney(100, usd),
+			to:     eur,
+			result: 92,
+		},
+		{
+			name:   "ToZeroExponent",
+			quote:  Quote{FromCurrency: "USD", ToCurrency: "JPY", Rate: 150 * RateScale},
+			amount: currency.NewMoney(1234, usd),
+			to:     jpy,
+			result: 1851,
+		},
+		{
+			name:   "FromZeroExponent",
+			quote:  Quote{FromCurrency: "JPY", ToCurrency: "USD", Rate: 666_667},
+			amount: currency.NewMoney(1500, jpy),
+			to:     usd,
+			result: 1000,
+		},
+		{
+			name:   "MaxAmount",
+			quote:  Quote{FromCurrency: "USD", ToCurrency: "EUR", Rate: RateScale},
+			amount: currency.NewMoney(math.MaxInt64, usd),
+			to:     eur,
+			result: math.MaxInt64,
+		},
+		{
+			name:   "Overflow",
+			quote:  Quote{FromCurrency: "USD", ToCurrency: "EUR", Rate: 2 * RateScale},
+			amount: currency.NewMoney(math.MaxInt64, usd),
+			to:     eur,
+			err:    ErrAmountOverflow,
+		},
+		{
+			name:   "CurrencyMismatch",
+			quote:  Quote{FromCurrency: "USD", ToCurrency: "EUR", Rate: RateScale},
+			amount: currency.NewMoney(100, usd),
+			to:     jpy,
+			err:    ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		money, err := tc.quote.Convert(tc.amount, tc.to)
+		require.ErrorIs(t, err, tc.err, tc.name)
+		require.Equal(t, tc.result, money.Amount, tc.name)
+		if tc.err == nil {
+			require.Equal(t, tc.to, money.Currency, tc.name)
+		}
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	testCases := []struct {
+		value string
+		rate  int64
+		err   error
+	}{
+		{value: "1", rate: RateScale},
+		{value: "0.92", rate: 92_000_000},
+		{value: "1.08345678", rate: 108_345_678},
+		{value: "24500", rate: 24500 * RateScale},
+		{value: "0", err: ErrInvalidRate},
+		{value: "-1", err: ErrInvalidRate},
+		{value: ".5", err: ErrInvalidRate},
+		{value: "1.123456789", err: ErrInvalidRate},
+		{value: "abc", err: ErrInvalidRate},
+		{value: "", err: ErrInvalidRate},
+	}
+
+	for _, tc := range testCases {
+		rate, err := ParseRate(tc.value)
+		require.ErrorIs(t, err, tc.err, tc.value)
+		require.Equal(t, tc.rate, rate, tc.value)
+
+		if tc.err == nil {
+			require.Equal(t, tc.value, FormatRate(rate))
+		}
+	}
+}
+
+func TestStaticRateProvider(t *testing.T) {
+	provider := NewStaticRateProvider()
+	provider.SetRate("USD", "EUR", 92_000_000)
+
+	quote, err := provider.GetQuote(context.Background(), "USD", "EUR")
+	require.NoError(t, err)
+	require.NotEmpty(t, quote.ID)
+	require.Equal(t, "USD", quote.FromCurrency)
+	require.Equal(t, "EUR", quote.ToCurrency)
+	require.Equal(t, int64(92_000_000), quote.Rate)
+	require.NotZero(t, quote.CreatedAt)
+
+	quote, err = provider.GetQuote(context.Background(), "EUR", "EUR")
+	require.NoError(t, err)
+	require.Equal(t, int64(RateScale), quote.Rate)
+
+	_, err = provider.GetQuote(context.Background(), "EUR", "USD")
+	require.ErrorIs(t, err, ErrRateNotFound)
+}
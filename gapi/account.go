@@ -0,0 +1,100 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getAuthorizedAccount loads the account and makes sure the authenticated user is allowed to access it.
+// Bankers can access any account, depositors only the ones they are a member of with the given permission.
+func (server *Server) getAuthorizedAccount(ctx context.Context, authPayload *token.Payload, accountID int64, permission string) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return account, status.Errorf(codes.NotFound, "account not found")
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if authPayload.Role == util.BankerRole {
+		return account, nil
+	}
+
+	err = server.authorizeAccount(ctx, authPayload, account, permission, 0)
+	return account, err
+}
+
+// authorizeAccount checks the membership of the authenticated user on the account.
+// The amount is only used by the spend permission, to enforce the limit of a spender.
+func (server *Server) authorizeAccount(ctx context.Context, authPayload *token.Payload, account db.Account, permission string, amount int64) error {
+	err := db.AuthorizeAccount(ctx, server.store, db.AuthorizeAccountParams{
+		Account:    account,
+		Username:   authPayload.Username,
+		Permission: permission,
+		Amount:     amount,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAccountAccessDenied) {
+			return status.Errorf(codes.PermissionDenied, "%s", err)
+		}
+		if errors.Is(err, db.ErrSpendLimitExceeded) {
+			return status.Errorf(codes.ResourceExhausted, "%s", err)
+		}
+		return status.Errorf(codes.Internal, "failed to get account member: %s", err)
+	}
+
+	return nil
+}
+
+// getAccount loads the account without any access check.
+func (server *Server) getAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	return account, nil
+}
+
+// validAccount loads the account and checks that it holds the given currency.
+// A currency mismatch is reported as a violation on the given request field.
+func (server *Server) validAccount(ctx context.Context, field string, accountID int64, currency string) (db.Account, error) {
+	account, err := server.getAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Currency != currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", accountID, account.Currency, currency)
+		return account, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolations(field, err)})
+	}
+
+	return account, nil
+}
+
+// updateAccountStatus moves the account to another status and records the reason of the change.
+func (server *Server) updateAccountStatus(ctx context.Context, arg db.UpdateAccountStatusTxParams) (db.Account, error) {
+	result, err := server.store.UpdateAccountStatusTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return result.Account, status.Errorf(codes.NotFound, "account not found")
+		}
+		if errors.Is(err, db.ErrInvalidStatusChange) || errors.Is(err, db.ErrNonZeroBalance) {
+			return result.Account, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return result.Account, status.Errorf(codes.Internal, "failed to update account status: %s", err)
+	}
+
+	return result.Account, nil
+}
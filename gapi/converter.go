@@ -1,9 +1,11 @@
 package gapi
 
 import (
+	"encoding/json"
 	"time"
 
 	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/fx"
 	"github.com/hoangtk0100/simple-bank/pb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -18,6 +20,230 @@ func convertUser(user db.User) *pb.User {
 	}
 }
 
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		CreatedAt:        convertTimestamp(account.CreatedAt),
+		Status:           account.Status,
+		AvailableBalance: account.AvailableBalance,
+		Tier:             account.Tier,
+		Product:          account.Product,
+	}
+}
+
+func convertEntry(entry db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:           entry.ID,
+		AccountId:    entry.AccountID,
+		Amount:       entry.Amount,
+		CreatedAt:    convertTimestamp(entry.CreatedAt),
+		BalanceAfter: entry.BalanceAfter,
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	rsp := &pb.Transfer{
+		Id:                   transfer.ID,
+		FromAccountId:        transfer.FromAccountID,
+		ToAccountId:          transfer.ToAccountID,
+		Amount:               transfer.Amount,
+		CreatedAt:            convertTimestamp(transfer.CreatedAt),
+		ToAmount:             transfer.ToAmount,
+		QuoteId:              transfer.QuoteID.String,
+		ReasonCode:           transfer.ReasonCode.String,
+		ExternalReference:    transfer.ExternalReference.String,
+		ReversalOfTransferId: transfer.ReversalOfTransferID.Int64,
+		Memo:                 transfer.Memo.String,
+		Recipient:            transfer.Recipient.String,
+	}
+	if transfer.ExchangeRate.Valid {
+		rsp.ExchangeRate = fx.FormatRate(transfer.ExchangeRate.Int64)
+	}
+
+	// The metadata is always written as a string map, a null column leaves it empty
+	_ = json.Unmarshal(transfer.Metadata, &rsp.Metadata)
+
+	return rsp
+}
+
+func convertCurrency(currency db.Currency) *pb.Currency {
+	return &pb.Currency{
+		Code:        currency.Code,
+		NumericCode: currency.NumericCode,
+		Exponent:    currency.Exponent,
+		Symbol:      currency.Symbol,
+		Enabled:     currency.Enabled,
+		UpdatedAt:   convertTimestamp(currency.UpdatedAt),
+	}
+}
+
+func convertExchangeRate(rate db.ExchangeRate) *pb.ExchangeRate {
+	return &pb.ExchangeRate{
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Rate:         fx.FormatRate(rate.Rate),
+		UpdatedBy:    rate.UpdatedBy,
+		UpdatedAt:    convertTimestamp(rate.UpdatedAt),
+	}
+}
+
+func convertReconciliationReport(report db.ReconciliationReport) *pb.ReconciliationReport {
+	return &pb.ReconciliationReport{
+		Id:           report.ID,
+		AccountId:    report.AccountID,
+		Balance:      report.Balance,
+		EntriesTotal: report.EntriesTotal,
+		Difference:   report.Difference,
+		CreatedAt:    convertTimestamp(report.CreatedAt),
+	}
+}
+
+func convertScheduledTransfer(scheduledTransfer db.ScheduledTransfer) *pb.ScheduledTransfer {
+	rsp := &pb.ScheduledTransfer{
+		Id:            scheduledTransfer.ID,
+		Owner:         scheduledTransfer.Owner,
+		FromAccountId: scheduledTransfer.FromAccountID,
+		ToAccountId:   scheduledTransfer.ToAccountID,
+		Amount:        scheduledTransfer.Amount,
+		Recurrence:    scheduledTransfer.Recurrence.String,
+		Status:        scheduledTransfer.Status,
+		CreatedAt:     convertTimestamp(scheduledTransfer.CreatedAt),
+		UpdatedAt:     convertTimestamp(scheduledTransfer.UpdatedAt),
+	}
+	if scheduledTransfer.NextRunAt.Valid {
+		rsp.NextRunAt = convertTimestamp(scheduledTransfer.NextRunAt.Time)
+	}
+
+	return rsp
+}
+
+func convertScheduledTransferRun(run db.ScheduledTransferRun) *pb.ScheduledTransferRun {
+	return &pb.ScheduledTransferRun{
+		Id:                  run.ID,
+		ScheduledTransferId: run.ScheduledTransferID,
+		ScheduledAt:         convertTimestamp(run.ScheduledAt),
+		Status:              run.Status,
+		TransferId:          run.TransferID.Int64,
+		Error:               run.Error.String,
+		CreatedAt:           convertTimestamp(run.CreatedAt),
+	}
+}
+
+func convertRiskDecision(decision db.RiskDecision) *pb.RiskDecision {
+	rsp := &pb.RiskDecision{
+		Id:            decision.ID,
+		Username:      decision.Username,
+		FromAccountId: decision.FromAccountID,
+		ToAccountId:   decision.ToAccountID,
+		Amount:        decision.Amount,
+		Decision:      decision.Decision,
+		Rules:         decision.Rules,
+		TransferId:    decision.TransferID.Int64,
+		HoldId:        decision.HoldID.Int64,
+		Resolution:    decision.Resolution.String,
+		ReviewedBy:    decision.ReviewedBy.String,
+		CreatedAt:     convertTimestamp(decision.CreatedAt),
+	}
+	if decision.ReviewedAt.Valid {
+		rsp.ReviewedAt = convertTimestamp(decision.ReviewedAt.Time)
+	}
+
+	return rsp
+}
+
+func convertFeeSchedule(schedule db.FeeSchedule) *pb.FeeSchedule {
+	return &pb.FeeSchedule{
+		Product:         schedule.Product,
+		Currency:        schedule.Currency,
+		MonthlyFee:      schedule.MonthlyFee,
+		TransferFee:     schedule.TransferFee,
+		MinimumBalance:  schedule.MinimumBalance,
+		BelowMinimumFee: schedule.BelowMinimumFee,
+		UpdatedBy:       schedule.UpdatedBy,
+		UpdatedAt:       convertTimestamp(schedule.UpdatedAt),
+	}
+}
+
+func convertFeeCharge(charge db.FeeCharge) *pb.FeeCharge {
+	return &pb.FeeCharge{
+		Id:                charge.ID,
+		AccountId:         charge.AccountID,
+		Kind:              charge.Kind,
+		Amount:            charge.Amount,
+		TransferId:        charge.TransferID.Int64,
+		ChargedTransferId: charge.ChargedTransferID.Int64,
+		CreatedAt:         convertTimestamp(charge.CreatedAt),
+	}
+}
+
+func convertTransferBatch(batch db.TransferBatch) *pb.TransferBatch {
+	rsp := &pb.TransferBatch{
+		Id:              batch.ID,
+		Owner:           batch.Owner,
+		FromAccountId:   batch.FromAccountID,
+		Status:          batch.Status,
+		RowCount:        batch.RowCount,
+		TotalAmount:     batch.TotalAmount,
+		SucceededCount:  batch.SucceededCount,
+		FailedCount:     batch.FailedCount,
+		SucceededAmount: batch.SucceededAmount,
+		CreatedAt:       convertTimestamp(batch.CreatedAt),
+	}
+	if batch.CompletedAt.Valid {
+		rsp.CompletedAt = convertTimestamp(batch.CompletedAt.Time)
+	}
+
+	return rsp
+}
+
+func convertTransferBatchRow(row db.TransferBatchRow) *pb.TransferBatchRow {
+	return &pb.TransferBatchRow{
+		Id:          row.ID,
+		RowNumber:   row.RowNumber,
+		ToAccountId: row.ToAccountID,
+		Amount:      row.Amount,
+		Reference:   row.Reference,
+		Status:      row.Status,
+		TransferId:  row.TransferID.Int64,
+		Error:       row.Error.String,
+		UpdatedAt:   convertTimestamp(row.UpdatedAt),
+	}
+}
+
+func convertPayee(payee db.Payee) *pb.Payee {
+	return &pb.Payee{
+		Id:          payee.ID,
+		Owner:       payee.Owner,
+		Nickname:    payee.Nickname,
+		ToAccountId: payee.ToAccountID.Int64,
+		ToUsername:  payee.ToUsername.String,
+		Currency:    payee.Currency,
+		Status:      payee.Status,
+		CreatedAt:   convertTimestamp(payee.CreatedAt),
+		UpdatedAt:   convertTimestamp(payee.UpdatedAt),
+	}
+}
+
+func convertAccountMember(member db.AccountMember) *pb.AccountMember {
+	rsp := &pb.AccountMember{
+		AccountId:  member.AccountID,
+		Username:   member.Username,
+		Role:       member.Role,
+		SpendLimit: member.SpendLimit.Int64,
+		Status:     member.Status,
+		InvitedBy:  member.InvitedBy,
+		CreatedAt:  convertTimestamp(member.CreatedAt),
+	}
+	if member.AcceptedAt.Valid {
+		rsp.AcceptedAt = convertTimestamp(member.AcceptedAt.Time)
+	}
+
+	return rsp
+}
+
 func convertTimestamp(input time.Time) *timestamppb.Timestamp {
 	return timestamppb.New(input)
 }
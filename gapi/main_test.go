@@ -39,3 +39,10 @@ func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username st
 
 	return metadata.NewIncomingContext(context.Background(), md)
 }
+
+func newContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = metadata.Join(md, metadata.Pairs(idempotencyKeyHeader, key))
+
+	return metadata.NewIncomingContext(ctx, md)
+}
@@ -0,0 +1,101 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/token"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getOwnedPayee loads the payee and makes sure it belongs to the authenticated user.
+// The address book is private to its owner, so bankers can not read it either.
+func (server *Server) getOwnedPayee(ctx context.Context, authPayload *token.Payload, id int64) (db.Payee, error) {
+	payee, err := server.store.GetPayee(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return payee, status.Errorf(codes.NotFound, "payee not found")
+		}
+		return payee, status.Errorf(codes.Internal, "failed to get payee: %s", err)
+	}
+
+	if payee.Owner != authPayload.Username {
+		return payee, status.Errorf(codes.PermissionDenied, "the payee doesn't belong to the authenticated user")
+	}
+
+	return payee, nil
+}
+
+// getActivePayee loads a payee of the authenticated user which can be paid.
+func (server *Server) getActivePayee(ctx context.Context, authPayload *token.Payload, id int64) (db.Payee, error) {
+	payee, err := server.getOwnedPayee(ctx, authPayload, id)
+	if err != nil {
+		return payee, err
+	}
+
+	if payee.Status != db.PayeeStatusActive {
+		return payee, status.Errorf(codes.FailedPrecondition, "payee is %s, confirm it with the code sent by email first", payee.Status)
+	}
+
+	return payee, nil
+}
+
+// getPayeeAccount resolves the account of an active payee used by a transfer in place of to_account_id.
+// A user payee is paid into their account in the currency of the transfer, which stays private as in CreatePayment.
+func (server *Server) getPayeeAccount(ctx context.Context, authPayload *token.Payload, payeeID int64, fromAccount db.Account) (account db.Account, private bool, err error) {
+	payee, err := server.getActivePayee(ctx, authPayload, payeeID)
+	if err != nil {
+		return account, false, err
+	}
+
+	if payee.ToAccountID.Valid {
+		if payee.ToAccountID.Int64 == fromAccount.ID {
+			err := fmt.Errorf("must be different from from_account_id")
+			return account, false, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolations("payee_id", err)})
+		}
+
+		account, err = server.getAccount(ctx, payee.ToAccountID.Int64)
+		return account, false, err
+	}
+
+	if payee.Currency != fromAccount.Currency {
+		err := fmt.Errorf("must be %s, the currency of the payee", payee.Currency)
+		return account, true, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolations("currency", err)})
+	}
+
+	account, err = server.getRecipientAccount(ctx, payee.ToUsername.String, "", payee.Currency)
+	if err != nil {
+		return account, true, err
+	}
+
+	if account.ID == fromAccount.ID {
+		err := fmt.Errorf("can not pay yourself")
+		return account, true, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolations("payee_id", err)})
+	}
+
+	return account, true, nil
+}
+
+// getPayeeUsername resolves the user of an active payee used by a payment in place of to_username.
+func (server *Server) getPayeeUsername(ctx context.Context, authPayload *token.Payload, payeeID int64, currency string) (string, error) {
+	payee, err := server.getActivePayee(ctx, authPayload, payeeID)
+	if err != nil {
+		return "", err
+	}
+
+	if !payee.ToUsername.Valid {
+		err := fmt.Errorf("is an account, pay it with CreateTransfer instead")
+		return "", invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolations("payee_id", err)})
+	}
+
+	if payee.Currency != currency {
+		err := fmt.Errorf("must be %s, the currency of the payee", payee.Currency)
+		return "", invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolations("currency", err)})
+	}
+
+	return payee.ToUsername.String, nil
+}
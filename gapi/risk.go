@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"errors"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/risk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// screeningError converts an error of the fraud screening, including the hold of a transfer sent for review
+func screeningError(err error) error {
+	if errors.Is(err, risk.ErrTransferBlocked) {
+		return status.Errorf(codes.PermissionDenied, "%s", err)
+	}
+	if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+		return status.Errorf(codes.AlreadyExists, "%s", err)
+	}
+	if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountNotActive) {
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+	if errors.Is(err, db.ErrSystemAccount) {
+		return status.Errorf(codes.PermissionDenied, "%s", err)
+	}
+	return status.Errorf(codes.Internal, "failed to screen transfer: %s", err)
+}
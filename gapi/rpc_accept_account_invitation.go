@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) AcceptAccountInvitation(ctx context.Context, req *pb.AcceptAccountInvitationRequest) (*pb.AcceptAccountInvitationResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAcceptAccountInvitationRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// Only a pending invitation of the authenticated user is accepted
+	member, err := server.store.AcceptAccountMember(ctx, db.AcceptAccountMemberParams{
+		AccountID: req.GetAccountId(),
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "invitation not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to accept invitation: %s", err)
+	}
+
+	rsp := &pb.AcceptAccountInvitationResponse{
+		Member: convertAccountMember(member),
+	}
+	return rsp, nil
+}
+
+func validateAcceptAccountInvitationRequest(req *pb.AcceptAccountInvitationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolations("account_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ConfirmPayee(ctx context.Context, req *pb.ConfirmPayeeRequest) (*pb.ConfirmPayeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateConfirmPayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	payee, err := server.getOwnedPayee(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if payee.Status != db.PayeeStatusPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "payee is %s", payee.Status)
+	}
+
+	// An expired code can not be sent again, the payee has to be deleted and added again
+	payee, err = server.store.ConfirmPayee(ctx, db.ConfirmPayeeParams{
+		ID: payee.ID,
+		ConfirmationCode: pgtype.Text{
+			String: req.GetConfirmationCode(),
+			Valid:  true,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+				fieldViolations("confirmation_code", fmt.Errorf("is wrong or expired")),
+			})
+		}
+		return nil, status.Errorf(codes.Internal, "failed to confirm payee: %s", err)
+	}
+
+	rsp := &pb.ConfirmPayeeResponse{
+		Payee: convertPayee(payee),
+	}
+	return rsp, nil
+}
+
+func validateConfirmPayeeRequest(req *pb.ConfirmPayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePayeeID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolations("id", err))
+	}
+
+	if err := val.ValidatePayeeConfirmationCode(req.GetConfirmationCode()); err != nil {
+		violations = append(violations, fieldViolations("confirmation_code", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,156 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConfirmPayee(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	payee := randomPayee(user1.Username, randomAccount(user2.Username))
+	payee.Status = db.PayeeStatusPending
+	code := util.RandomString(8)
+
+	buildContext := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+	}
+
+	arg := db.ConfirmPayeeParams{
+		ID: payee.ID,
+		ConfirmationCode: pgtype.Text{
+			String: code,
+			Valid:  true,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ConfirmPayeeRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ConfirmPayeeResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ConfirmPayeeRequest{
+				Id:               payee.ID,
+				ConfirmationCode: code,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+
+				confirmed := payee
+				confirmed.Status = db.PayeeStatusActive
+				store.EXPECT().ConfirmPayee(gomock.Any(), gomock.Eq(arg)).Times(1).Return(confirmed, nil)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.ConfirmPayeeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.PayeeStatusActive, res.GetPayee().GetStatus())
+			},
+		},
+		{
+			name: "WrongOrExpiredCode",
+			req: &pb.ConfirmPayeeRequest{
+				Id:               payee.ID,
+				ConfirmationCode: code,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().ConfirmPayee(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Payee{}, db.ErrRecordNotFound)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.ConfirmPayeeResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "confirmation_code")
+			},
+		},
+		{
+			name: "AlreadyActive",
+			req: &pb.ConfirmPayeeRequest{
+				Id:               payee.ID,
+				ConfirmationCode: code,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				active := payee
+				active.Status = db.PayeeStatusActive
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(active, nil)
+				store.EXPECT().ConfirmPayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.ConfirmPayeeResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req: &pb.ConfirmPayeeRequest{
+				Id:               payee.ID,
+				ConfirmationCode: code,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().ConfirmPayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, user2.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ConfirmPayeeResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "InvalidCode",
+			req: &pb.ConfirmPayeeRequest{
+				Id:               payee.ID,
+				ConfirmationCode: "abc",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.ConfirmPayeeResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "confirmation_code")
+			},
+		},
+	}
+
+	for index := range testCases {
+		tc := testCases[index]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.ConfirmPayee(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/hoangtk0100/simple-bank/currency"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateCurrency(ctx context.Context, req *pb.CreateCurrencyRequest) (*pb.CreateCurrencyResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{util.BankerRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateCurrencyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.CreateCurrencyParams{
+		Code:        req.GetCode(),
+		NumericCode: req.GetNumericCode(),
+		Exponent:    req.GetExponent(),
+		Symbol:      req.GetSymbol(),
+		Enabled:     req.GetEnabled(),
+	}
+
+	result, err := server.store.CreateCurrency(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "currency already exists: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create currency: %s", err)
+	}
+
+	server.currencies.Set(currency.FromDB(result))
+
+	rsp := &pb.CreateCurrencyResponse{
+		Currency: convertCurrency(result),
+	}
+	return rsp, nil
+}
+
+func validateCreateCurrencyRequest(req *pb.CreateCurrencyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrencyCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolations("code", err))
+	}
+
+	if err := val.ValidateCurrencyNumericCode(req.GetNumericCode()); err != nil {
+		violations = append(violations, fieldViolations("numeric_code", err))
+	}
+
+	if err := val.ValidateCurrencyExponent(req.GetExponent()); err != nil {
+		violations = append(violations, fieldViolations("exponent", err))
+	}
+
+	if err := val.ValidateCurrencySymbol(req.GetSymbol()); err != nil {
+		violations = append(violations, fieldViolations("symbol", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,118 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"github.com/hoangtk0100/simple-bank/worker"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreatePayee(ctx context.Context, req *pb.CreatePayeeRequest) (*pb.CreatePayeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreatePayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if req.GetToAccountId() != 0 {
+		toAccount, err := server.validAccount(ctx, "to_account_id", req.GetToAccountId(), req.GetCurrency())
+		if err != nil {
+			return nil, err
+		}
+
+		if db.IsSystemAccount(toAccount) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s", db.ErrSystemAccount)
+		}
+	}
+
+	// A user payee is not looked up, so the address book does not tell which users are registered
+	if req.GetToUsername() == authPayload.Username {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolations("to_username", fmt.Errorf("can not add yourself")),
+		})
+	}
+
+	arg := db.CreatePayeeTxParams{
+		CreatePayeeParams: db.CreatePayeeParams{
+			Owner:    authPayload.Username,
+			Nickname: req.GetNickname(),
+			ToAccountID: pgtype.Int8{
+				Int64: req.GetToAccountId(),
+				Valid: req.GetToAccountId() != 0,
+			},
+			ToUsername: pgtype.Text{
+				String: req.GetToUsername(),
+				Valid:  req.GetToUsername() != "",
+			},
+			Currency: req.GetCurrency(),
+			Status:   db.PayeeStatusActive,
+		},
+	}
+
+	if server.config.PayeeConfirmationRequired {
+		arg.Status = db.PayeeStatusPending
+		arg.AfterCreate = func(payee db.Payee) error {
+			taskPayload := &worker.PayloadSendPayeeConfirmationEmail{
+				PayeeID: payee.ID,
+			}
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueCritical),
+			}
+			return server.taskDistributor.DistributeTaskSendPayeeConfirmationEmail(ctx, taskPayload, opts...)
+		}
+	}
+
+	result, err := server.store.CreatePayeeTx(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "payee nickname already exists: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create payee: %s", err)
+	}
+
+	rsp := &pb.CreatePayeeResponse{
+		Payee: convertPayee(result.Payee),
+	}
+	return rsp, nil
+}
+
+func validateCreatePayeeRequest(req *pb.CreatePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePayeeNickname(req.GetNickname()); err != nil {
+		violations = append(violations, fieldViolations("nickname", err))
+	}
+
+	switch {
+	case req.GetToAccountId() != 0 && req.GetToUsername() != "":
+		violations = append(violations, fieldViolations("to_username", fmt.Errorf("must not be set together with to_account_id")))
+	case req.GetToUsername() != "":
+		if err := val.ValidateUsername(req.GetToUsername()); err != nil {
+			violations = append(violations, fieldViolations("to_username", err))
+		}
+	default:
+		if err := val.ValidateAccountID(req.GetToAccountId()); err != nil {
+			violations = append(violations, fieldViolations("to_account_id", err))
+		}
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolations("currency", err))
+	}
+
+	return violations
+}
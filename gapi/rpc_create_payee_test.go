@@ -0,0 +1,257 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/worker"
+	mockwk "github.com/hoangtk0100/simple-bank/worker/mock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomPayee(owner string, toAccount db.Account) db.Payee {
+	return db.Payee{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    owner,
+		Nickname: util.RandomOwner(),
+		ToAccountID: pgtype.Int8{
+			Int64: toAccount.ID,
+			Valid: true,
+		},
+		Currency: toAccount.Currency,
+		Status:   db.PayeeStatusActive,
+	}
+}
+
+func randomUserPayee(owner string, toUsername string, currency string) db.Payee {
+	return db.Payee{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    owner,
+		Nickname: util.RandomOwner(),
+		ToUsername: pgtype.Text{
+			String: toUsername,
+			Valid:  true,
+		},
+		Currency: currency,
+		Status:   db.PayeeStatusActive,
+	}
+}
+
+func TestCreatePayee(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account2 := randomAccount(user2.Username)
+	account2.Currency = util.USD
+	payee := randomPayee(user1.Username, account2)
+
+	buildContext := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+	}
+
+	testCases := []struct {
+		name                 string
+		req                  *pb.CreatePayeeRequest
+		confirmationRequired bool
+		buildStubs           func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		buildContext         func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse        func(t *testing.T, res *pb.CreatePayeeResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreatePayeeRequest{
+				Nickname:    payee.Nickname,
+				ToAccountId: account2.ID,
+				Currency:    account2.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					CreatePayeeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreatePayeeTxParams) (db.CreatePayeeTxResult, error) {
+						require.Equal(t, user1.Username, arg.Owner)
+						require.Equal(t, payee.ToAccountID, arg.ToAccountID)
+						require.False(t, arg.ToUsername.Valid)
+						require.Equal(t, db.PayeeStatusActive, arg.Status)
+						require.Nil(t, arg.AfterCreate)
+						return db.CreatePayeeTxResult{Payee: payee}, nil
+					})
+				taskDistributor.EXPECT().DistributeTaskSendPayeeConfirmationEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, payee.ID, res.GetPayee().GetId())
+				require.Equal(t, account2.ID, res.GetPayee().GetToAccountId())
+				require.Equal(t, db.PayeeStatusActive, res.GetPayee().GetStatus())
+			},
+		},
+		{
+			name: "ConfirmationRequired",
+			req: &pb.CreatePayeeRequest{
+				Nickname:   payee.Nickname,
+				ToUsername: user2.Username,
+				Currency:   account2.Currency,
+			},
+			confirmationRequired: true,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				// A user payee is not looked up
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+
+				pending := randomUserPayee(user1.Username, user2.Username, account2.Currency)
+				pending.Status = db.PayeeStatusPending
+				store.EXPECT().
+					CreatePayeeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreatePayeeTxParams) (db.CreatePayeeTxResult, error) {
+						require.Equal(t, user2.Username, arg.ToUsername.String)
+						require.False(t, arg.ToAccountID.Valid)
+						require.Equal(t, db.PayeeStatusPending, arg.Status)
+						return db.CreatePayeeTxResult{Payee: pending}, arg.AfterCreate(pending)
+					})
+
+				taskPayload := &worker.PayloadSendPayeeConfirmationEmail{
+					PayeeID: pending.ID,
+				}
+				taskDistributor.EXPECT().
+					DistributeTaskSendPayeeConfirmationEmail(gomock.Any(), taskPayload, gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user2.Username, res.GetPayee().GetToUsername())
+				require.Equal(t, db.PayeeStatusPending, res.GetPayee().GetStatus())
+			},
+		},
+		{
+			name: "BothTargets",
+			req: &pb.CreatePayeeRequest{
+				Nickname:    payee.Nickname,
+				ToAccountId: account2.ID,
+				ToUsername:  user2.Username,
+				Currency:    account2.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreatePayeeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "to_username")
+			},
+		},
+		{
+			name: "Yourself",
+			req: &pb.CreatePayeeRequest{
+				Nickname:   payee.Nickname,
+				ToUsername: user1.Username,
+				Currency:   account2.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreatePayeeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "to_username")
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			req: &pb.CreatePayeeRequest{
+				Nickname:    payee.Nickname,
+				ToAccountId: account2.ID,
+				Currency:    util.EUR,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CreatePayeeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "to_account_id")
+			},
+		},
+		{
+			name: "NicknameExists",
+			req: &pb.CreatePayeeRequest{
+				Nickname:    payee.Nickname,
+				ToAccountId: account2.ID,
+				Currency:    account2.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					CreatePayeeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreatePayeeTxResult{}, db.ErrUniqueViolation)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.AlreadyExists, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.CreatePayeeRequest{
+				Nickname:    payee.Nickname,
+				ToAccountId: account2.ID,
+				Currency:    account2.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreatePayeeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CreatePayeeResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for index := range testCases {
+		tc := testCases[index]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServer(t, store, taskDistributor)
+			server.config.PayeeConfirmationRequired = tc.confirmationRequired
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CreatePayee(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
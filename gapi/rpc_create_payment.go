@@ -0,0 +1,229 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	paymentStatusCompleted     = "completed"
+	paymentStatusPendingReview = "pending_review"
+)
+
+func (server *Server) CreatePayment(ctx context.Context, req *pb.CreatePaymentRequest) (*pb.CreatePaymentResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	violations := validateCreatePaymentRequest(req)
+	if mtdt.IdempotencyKey != "" {
+		if err := val.ValidateIdempotencyKey(mtdt.IdempotencyKey); err != nil {
+			violations = append(violations, fieldViolations(idempotencyKeyHeader, err))
+		}
+	}
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, "from_account_id", req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.authorizeAccount(ctx, authPayload, fromAccount, db.AccountPermissionSpend, req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+
+	toUsername := req.GetToUsername()
+	if req.GetPayeeId() != 0 {
+		toUsername, err = server.getPayeeUsername(ctx, authPayload, req.GetPayeeId(), req.GetCurrency())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	toAccount, err := server.getRecipientAccount(ctx, toUsername, req.GetToEmail(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if toAccount.ID == fromAccount.ID {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolations("to_username", fmt.Errorf("can not pay yourself")),
+		})
+	}
+
+	// The recipient is recorded as the payer knows them, so the transfer never shows them the account
+	recipient := toUsername
+	if req.GetToEmail() != "" {
+		recipient = req.GetToEmail()
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        req.GetAmount(),
+		TransferDetails: db.TransferDetails{
+			Memo:              req.GetMemo(),
+			ExternalReference: req.GetExternalReference(),
+			Metadata:          req.GetMetadata(),
+			Recipient:         recipient,
+		},
+	}
+
+	if mtdt.IdempotencyKey != "" {
+		arg.Idempotency = &db.IdempotencyParams{
+			Username: authPayload.Username,
+			Key:      mtdt.IdempotencyKey,
+			TTL:      server.config.IdempotencyKeyTTL,
+		}
+	}
+
+	outcome, err := server.sendTransfer(ctx, authPayload.Username, arg, fromAccount, toAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the side of the payer is returned, the account of the recipient stays private
+	payment := &pb.Payment{
+		Recipient:         recipient,
+		FromAccountId:     fromAccount.ID,
+		Amount:            req.GetAmount(),
+		Currency:          req.GetCurrency(),
+		Memo:              req.GetMemo(),
+		ExternalReference: req.GetExternalReference(),
+		Metadata:          req.GetMetadata(),
+	}
+
+	if outcome.Review != nil {
+		payment.Status = paymentStatusPendingReview
+		payment.RiskDecisionId = outcome.Review.RiskDecision.ID
+		payment.CreatedAt = convertTimestamp(outcome.Review.RiskDecision.CreatedAt)
+
+		rsp := &pb.CreatePaymentResponse{
+			Payment:     payment,
+			FromAccount: convertAccount(outcome.Review.FromAccount),
+		}
+		return rsp, nil
+	}
+
+	result := outcome.Transfer
+	payment.Status = paymentStatusCompleted
+	payment.TransferId = result.Transfer.ID
+	payment.CreatedAt = convertTimestamp(result.Transfer.CreatedAt)
+
+	rsp := &pb.CreatePaymentResponse{
+		Payment:     payment,
+		FromAccount: convertAccount(result.FromAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		Fees:        make([]*pb.FeeCharge, 0, len(result.Fees)),
+	}
+	for _, fee := range result.Fees {
+		rsp.Fees = append(rsp.Fees, convertFeeCharge(fee.Charge))
+	}
+	return rsp, nil
+}
+
+// getRecipientAccount finds the account in the currency owned by the user with the username or the verified email.
+// Every recipient which can not be paid is reported the same way, so the RPC does not tell which users are registered.
+func (server *Server) getRecipientAccount(ctx context.Context, username string, email string, currency string) (db.Account, error) {
+	notFound := status.Errorf(codes.NotFound, "recipient not found or has no account in this currency")
+
+	if email != "" {
+		user, err := server.store.GetUserByEmail(ctx, email)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				return db.Account{}, notFound
+			}
+			return db.Account{}, status.Errorf(codes.Internal, "failed to get recipient: %s", err)
+		}
+
+		if !user.IsEmailVerified {
+			return db.Account{}, notFound
+		}
+
+		username = user.Username
+	}
+
+	// An owner holds at most one account per currency
+	account, err := server.store.GetAccountByOwnerCurrency(ctx, db.GetAccountByOwnerCurrencyParams{
+		Owner:    username,
+		Currency: currency,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.Account{}, notFound
+		}
+		return db.Account{}, status.Errorf(codes.Internal, "failed to get recipient account: %s", err)
+	}
+
+	if db.IsSystemAccount(account) {
+		return db.Account{}, notFound
+	}
+
+	return account, nil
+}
+
+func validateCreatePaymentRequest(req *pb.CreatePaymentRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolations("from_account_id", err))
+	}
+
+	switch {
+	case req.GetPayeeId() != 0 && (req.GetToUsername() != "" || req.GetToEmail() != ""):
+		violations = append(violations, fieldViolations("payee_id", fmt.Errorf("must not be set together with to_username or to_email")))
+	case req.GetPayeeId() != 0:
+		if err := val.ValidatePayeeID(req.GetPayeeId()); err != nil {
+			violations = append(violations, fieldViolations("payee_id", err))
+		}
+	case req.GetToUsername() != "" && req.GetToEmail() != "":
+		violations = append(violations, fieldViolations("to_email", fmt.Errorf("must not be set together with to_username")))
+	case req.GetToEmail() != "":
+		if err := val.ValidateEmail(req.GetToEmail()); err != nil {
+			violations = append(violations, fieldViolations("to_email", err))
+		}
+	default:
+		if err := val.ValidateUsername(req.GetToUsername()); err != nil {
+			violations = append(violations, fieldViolations("to_username", err))
+		}
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolations("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolations("currency", err))
+	}
+
+	if req.GetMemo() != "" {
+		if err := val.ValidateMemo(req.GetMemo()); err != nil {
+			violations = append(violations, fieldViolations("memo", err))
+		}
+	}
+
+	if req.GetExternalReference() != "" {
+		if err := val.ValidateExternalReference(req.GetExternalReference()); err != nil {
+			violations = append(violations, fieldViolations("external_reference", err))
+		}
+	}
+
+	if err := val.ValidateMetadata(req.GetMetadata()); err != nil {
+		violations = append(violations, fieldViolations("metadata", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,112 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateScheduledTransfer(ctx context.Context, req *pb.CreateScheduledTransferRequest) (*pb.CreateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, "from_account_id", req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.authorizeAccount(ctx, authPayload, fromAccount, db.AccountPermissionSpend, req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+
+	// The amount is fixed in the currency of both accounts, the rate at run time is unknown
+	_, err = server.validAccount(ctx, "to_account_id", req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.CreateScheduledTransferParams{
+		Owner:         authPayload.Username,
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+		Recurrence: pgtype.Text{
+			String: req.GetRecurrence(),
+			Valid:  req.GetRecurrence() != "",
+		},
+		NextRunAt: pgtype.Timestamptz{
+			Time:  req.GetFirstRunAt().AsTime(),
+			Valid: true,
+		},
+	}
+
+	if req.FirstRunAt == nil {
+		// Already validated above
+		arg.NextRunAt.Time, _ = util.NextRecurrence(req.GetRecurrence(), time.Now())
+	}
+
+	scheduledTransfer, err := server.store.CreateScheduledTransfer(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create scheduled transfer: %s", err)
+	}
+
+	rsp := &pb.CreateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+	return rsp, nil
+}
+
+func validateCreateScheduledTransferRequest(req *pb.CreateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolations("from_account_id", err))
+	}
+
+	if err := val.ValidateAccountID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolations("to_account_id", err))
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolations("to_account_id", fmt.Errorf("must be different from from_account_id")))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolations("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolations("currency", err))
+	}
+
+	if req.GetRecurrence() != "" {
+		if err := val.ValidateRecurrence(req.GetRecurrence()); err != nil {
+			violations = append(violations, fieldViolations("recurrence", err))
+		}
+	}
+
+	if req.FirstRunAt != nil {
+		if err := val.ValidateFutureTime(req.GetFirstRunAt().AsTime()); err != nil {
+			violations = append(violations, fieldViolations("first_run_at", err))
+		}
+	} else if req.GetRecurrence() == "" {
+		violations = append(violations, fieldViolations("first_run_at", fmt.Errorf("is required for a one-off transfer")))
+	}
+
+	return violations
+}
@@ -0,0 +1,268 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hoangtk0100/simple-bank/currency"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/fx"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/risk"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	violations := validateCreateTransferRequest(req)
+	if mtdt.IdempotencyKey != "" {
+		if err := val.ValidateIdempotencyKey(mtdt.IdempotencyKey); err != nil {
+			violations = append(violations, fieldViolations(idempotencyKeyHeader, err))
+		}
+	}
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, "from_account_id", req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.authorizeAccount(ctx, authPayload, fromAccount, db.AccountPermissionSpend, req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+
+	// The to account may hold another currency, the amount is converted at the current rate then
+	var toAccount db.Account
+	private := false
+	if req.GetPayeeId() != 0 {
+		toAccount, private, err = server.getPayeeAccount(ctx, authPayload, req.GetPayeeId(), fromAccount)
+	} else {
+		toAccount, err = server.getAccount(ctx, req.GetToAccountId())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   toAccount.ID,
+		Amount:        req.GetAmount(),
+		TransferDetails: db.TransferDetails{
+			Memo:              req.GetMemo(),
+			ExternalReference: req.GetExternalReference(),
+			Metadata:          req.GetMetadata(),
+		},
+	}
+
+	// A user payee is paid like a payment, so the account looked up for the sender stays private
+	if private {
+		arg.Recipient = toAccount.Owner
+	}
+
+	if mtdt.IdempotencyKey != "" {
+		arg.Idempotency = &db.IdempotencyParams{
+			Username: authPayload.Username,
+			Key:      mtdt.IdempotencyKey,
+			TTL:      server.config.IdempotencyKeyTTL,
+		}
+	}
+
+	outcome, err := server.sendTransfer(ctx, authPayload.Username, arg, fromAccount, toAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	if outcome.Review != nil {
+		rsp := &pb.CreateTransferResponse{
+			FromAccount:  convertAccount(outcome.Review.FromAccount),
+			RiskDecision: convertRiskDecision(outcome.Review.RiskDecision),
+		}
+		return rsp, nil
+	}
+
+	result := outcome.Transfer
+	rsp := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(db.RedactRecipient(result.Transfer, fromAccount.ID)),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+		Fees:        make([]*pb.FeeCharge, 0, len(result.Fees)),
+	}
+	for _, fee := range result.Fees {
+		rsp.Fees = append(rsp.Fees, convertFeeCharge(fee.Charge))
+	}
+
+	// Only the side of the sender is returned when the account of a user payee was looked up for them
+	if private {
+		rsp.ToAccount = nil
+		rsp.ToEntry = nil
+	}
+	return rsp, nil
+}
+
+// transferOutcome is either the completed transfer or the hold on its funds when the fraud screening asked for a review
+type transferOutcome struct {
+	Transfer *db.TransferTxResult
+	Review   *db.HoldTransferForReviewTxResult
+}
+
+// sendTransfer screens the transfer then moves the money, converting the amount when the accounts hold different currencies
+func (server *Server) sendTransfer(
+	ctx context.Context,
+	username string,
+	arg db.TransferTxParams,
+	fromAccount db.Account,
+	toAccount db.Account,
+) (transferOutcome, error) {
+	screening, err := server.riskEngine.Screen(ctx, server.store, risk.ScreenParams{
+		Transfer: risk.Transfer{
+			Username:    username,
+			FromAccount: fromAccount,
+			ToAccount:   toAccount,
+			Amount:      arg.Amount,
+		},
+		Request:      arg,
+		HoldDuration: server.config.RiskReviewHoldDuration,
+	})
+	if err != nil {
+		return transferOutcome{}, screeningError(err)
+	}
+
+	if screening.Allowed == nil {
+		return transferOutcome{Transfer: screening.Transfer, Review: screening.Review}, nil
+	}
+
+	arg = *screening.Allowed
+
+	var result db.TransferTxResult
+	if toAccount.Currency == fromAccount.Currency {
+		result, err = server.store.TransferTx(ctx, arg)
+	} else {
+		var crossArg db.CrossCurrencyTransferTxParams
+		crossArg, err = server.crossCurrencyTransferParams(ctx, arg, fromAccount.Currency, toAccount.Currency)
+		if err != nil {
+			return transferOutcome{}, err
+		}
+
+		result, err = server.store.CrossCurrencyTransferTx(ctx, crossArg)
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			return transferOutcome{}, status.Errorf(codes.AlreadyExists, "%s", err)
+		}
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountNotActive) {
+			return transferOutcome{}, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if errors.Is(err, db.ErrSystemAccount) {
+			return transferOutcome{}, status.Errorf(codes.PermissionDenied, "%s", err)
+		}
+		if errors.Is(err, db.ErrTransferLimitExceeded) {
+			return transferOutcome{}, status.Errorf(codes.ResourceExhausted, "%s", err)
+		}
+		if errors.Is(err, db.ErrRecordNotFound) || db.ErrorCode(err) == db.ForeignKeyViolation {
+			return transferOutcome{}, status.Errorf(codes.NotFound, "account not found: %s", err)
+		}
+		return transferOutcome{}, status.Errorf(codes.Internal, "failed to transfer: %s", err)
+	}
+
+	return transferOutcome{Transfer: &result}, nil
+}
+
+// crossCurrencyTransferParams quotes the current rate and converts the amount into the currency of the to account
+func (server *Server) crossCurrencyTransferParams(
+	ctx context.Context,
+	arg db.TransferTxParams,
+	fromCurrency string,
+	toCurrency string,
+) (db.CrossCurrencyTransferTxParams, error) {
+	quote, err := server.rateProvider.GetQuote(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		if errors.Is(err, fx.ErrRateNotFound) {
+			return db.CrossCurrencyTransferTxParams{}, status.Errorf(codes.FailedPrecondition, "no exchange rate from %s to %s", fromCurrency, toCurrency)
+		}
+		return db.CrossCurrencyTransferTxParams{}, status.Errorf(codes.Internal, "failed to get exchange rate: %s", err)
+	}
+
+	from, fromFound := server.currencies.Get(fromCurrency)
+	to, toFound := server.currencies.Get(toCurrency)
+	if !fromFound || !toFound {
+		return db.CrossCurrencyTransferTxParams{}, status.Errorf(codes.Internal, "unknown currency %s or %s", fromCurrency, toCurrency)
+	}
+
+	toAmount, err := quote.Convert(currency.NewMoney(arg.Amount, from), to)
+	if err != nil || toAmount.Amount <= 0 {
+		err := fmt.Errorf("can not be converted from %s to %s", fromCurrency, toCurrency)
+		return db.CrossCurrencyTransferTxParams{}, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolations("amount", err)})
+	}
+
+	return db.CrossCurrencyTransferTxParams{
+		TransferTxParams: arg,
+		ToAmount:         toAmount.Amount,
+		ExchangeRate:     quote.Rate,
+		QuoteID:          quote.ID,
+	}, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolations("from_account_id", err))
+	}
+
+	switch {
+	case req.GetPayeeId() != 0 && req.GetToAccountId() != 0:
+		violations = append(violations, fieldViolations("payee_id", fmt.Errorf("must not be set together with to_account_id")))
+	case req.GetPayeeId() != 0:
+		if err := val.ValidatePayeeID(req.GetPayeeId()); err != nil {
+			violations = append(violations, fieldViolations("payee_id", err))
+		}
+	default:
+		if err := val.ValidateAccountID(req.GetToAccountId()); err != nil {
+			violations = append(violations, fieldViolations("to_account_id", err))
+		}
+
+		if req.GetFromAccountId() == req.GetToAccountId() {
+			violations = append(violations, fieldViolations("to_account_id", fmt.Errorf("must be different from from_account_id")))
+		}
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolations("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolations("currency", err))
+	}
+
+	if req.GetMemo() != "" {
+		if err := val.ValidateMemo(req.GetMemo()); err != nil {
+			violations = append(violations, fieldViolations("memo", err))
+		}
+	}
+
+	if req.GetExternalReference() != "" {
+		if err := val.ValidateExternalReference(req.GetExternalReference()); err != nil {
+			violations = append(violations, fieldViolations("external_reference", err))
+		}
+	}
+
+	if err := val.ValidateMetadata(req.GetMetadata()); err != nil {
+		violations = append(violations, fieldViolations("metadata", err))
+	}
+
+	return violations
+}
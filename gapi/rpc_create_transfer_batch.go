@@ -0,0 +1,269 @@
+package gapi
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"github.com/hoangtk0100/simple-bank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTransferBatchItems is the largest number of transfers accepted in one batch
+const maxTransferBatchItems = 1000
+
+// transferBatchItem is a transfer of the batch with the request field it was read from
+type transferBatchItem struct {
+	field       string
+	toAccountID int64
+	amount      int64
+	reference   string
+}
+
+func (server *Server) CreateTransferBatch(ctx context.Context, req *pb.CreateTransferBatchRequest) (*pb.CreateTransferBatchResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	items, violations := validateCreateTransferBatchRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, "from_account_id", req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	// The whole batch counts against the limit of a spender
+	var total int64
+	for _, item := range items {
+		total += item.amount
+	}
+
+	err = server.authorizeAccount(ctx, authPayload, fromAccount, db.AccountPermissionSpend, total)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every item is checked before the batch is accepted, so only the bank rules can fail a row later
+	violations, err = server.validateTransferBatchAccounts(ctx, items, fromAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.CreateTransferBatchTxParams{
+		Owner:         authPayload.Username,
+		FromAccountID: fromAccount.ID,
+		Rows:          make([]db.CreateTransferBatchRowParams, 0, len(items)),
+		AfterCreate: func(batch db.TransferBatch) error {
+			taskPayload := &worker.PayloadProcessTransferBatch{
+				BatchID: batch.ID,
+			}
+			opts := []asynq.Option{
+				asynq.TaskID(fmt.Sprintf("transfer_batch:%d", batch.ID)),
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueCritical),
+			}
+			return server.taskDistributor.DistributeTaskProcessTransferBatch(ctx, taskPayload, opts...)
+		},
+	}
+	for i, item := range items {
+		arg.Rows = append(arg.Rows, db.CreateTransferBatchRowParams{
+			RowNumber:   int32(i + 1),
+			ToAccountID: item.toAccountID,
+			Amount:      item.amount,
+			Reference:   item.reference,
+		})
+	}
+
+	result, err := server.store.CreateTransferBatchTx(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create transfer batch: %s", err)
+	}
+
+	rsp := &pb.CreateTransferBatchResponse{
+		Batch: convertTransferBatch(result.Batch),
+	}
+	return rsp, nil
+}
+
+// validateTransferBatchAccounts checks that the to account of every item exists, holds the currency of the batch
+// and can receive customer transfers
+func (server *Server) validateTransferBatchAccounts(
+	ctx context.Context,
+	items []transferBatchItem,
+	currency string,
+) (violations []*errdetails.BadRequest_FieldViolation, err error) {
+	accounts := make(map[int64]db.Account)
+
+	for _, item := range items {
+		account, found := accounts[item.toAccountID]
+		if !found {
+			account, err = server.store.GetAccount(ctx, item.toAccountID)
+			if err != nil {
+				if errors.Is(err, db.ErrRecordNotFound) {
+					err := fmt.Errorf("account [%d] not found", item.toAccountID)
+					violations = append(violations, fieldViolations(item.field+".to_account_id", err))
+					continue
+				}
+				return nil, status.Errorf(codes.Internal, "failed to get account: %s", err)
+			}
+
+			accounts[item.toAccountID] = account
+		}
+
+		if db.IsSystemAccount(account) {
+			violations = append(violations, fieldViolations(item.field+".to_account_id", db.ErrSystemAccount))
+			continue
+		}
+
+		if account.Currency != currency {
+			err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+			violations = append(violations, fieldViolations(item.field+".to_account_id", err))
+		}
+	}
+
+	return violations, nil
+}
+
+func validateCreateTransferBatchRequest(req *pb.CreateTransferBatchRequest) (items []transferBatchItem, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolations("from_account_id", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolations("currency", err))
+	}
+
+	switch {
+	case len(req.GetItems()) > 0 && req.GetCsv() != "":
+		violations = append(violations, fieldViolations("csv", fmt.Errorf("can not be set together with items")))
+		return nil, violations
+	case req.GetCsv() != "":
+		var csvViolations []*errdetails.BadRequest_FieldViolation
+		items, csvViolations = parseTransferBatchCSV(req.GetCsv())
+		violations = append(violations, csvViolations...)
+	default:
+		for i, item := range req.GetItems() {
+			items = append(items, transferBatchItem{
+				field:       fmt.Sprintf("items[%d]", i),
+				toAccountID: item.GetToAccountId(),
+				amount:      item.GetAmount(),
+				reference:   item.GetReference(),
+			})
+		}
+	}
+
+	source := "items"
+	if req.GetCsv() != "" {
+		source = "csv"
+	}
+
+	if len(items) == 0 && violations == nil {
+		violations = append(violations, fieldViolations(source, fmt.Errorf("must contain at least 1 transfer")))
+	}
+
+	if len(items) > maxTransferBatchItems {
+		violations = append(violations, fieldViolations(source, fmt.Errorf("must contain at most %d transfers", maxTransferBatchItems)))
+		return nil, violations
+	}
+
+	for _, item := range items {
+		if err := val.ValidateAccountID(item.toAccountID); err != nil {
+			violations = append(violations, fieldViolations(item.field+".to_account_id", err))
+		}
+
+		if item.toAccountID == req.GetFromAccountId() {
+			violations = append(violations, fieldViolations(item.field+".to_account_id", fmt.Errorf("must be different from from_account_id")))
+		}
+
+		if err := val.ValidateAmount(item.amount); err != nil {
+			violations = append(violations, fieldViolations(item.field+".amount", err))
+		}
+
+		if item.reference != "" {
+			if err := val.ValidateExternalReference(item.reference); err != nil {
+				violations = append(violations, fieldViolations(item.field+".reference", err))
+			}
+		}
+	}
+
+	return items, violations
+}
+
+// parseTransferBatchCSV reads the items of a batch from lines of to_account_id,amount,reference
+// The reference is optional and so is a first line naming the columns
+func parseTransferBatchCSV(data string) (items []transferBatchItem, violations []*errdetails.BadRequest_FieldViolation) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			violations = append(violations, fieldViolations("csv", err))
+			return nil, violations
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := fmt.Sprintf("csv[%d]", line)
+
+		if line == 1 && record[0] == "to_account_id" {
+			continue
+		}
+
+		if len(record) < 2 || len(record) > 3 {
+			violations = append(violations, fieldViolations(field, fmt.Errorf("must have 2 or 3 columns: to_account_id,amount,reference")))
+			continue
+		}
+
+		item := transferBatchItem{
+			field: field,
+		}
+
+		var toAccountErr, amountErr error
+		item.toAccountID, toAccountErr = strconv.ParseInt(record[0], 10, 64)
+		if toAccountErr != nil {
+			violations = append(violations, fieldViolations(field+".to_account_id", fmt.Errorf("must be an integer")))
+		}
+
+		item.amount, amountErr = strconv.ParseInt(record[1], 10, 64)
+		if amountErr != nil {
+			violations = append(violations, fieldViolations(field+".amount", fmt.Errorf("must be an integer in minor units")))
+		}
+
+		if toAccountErr != nil || amountErr != nil {
+			continue
+		}
+
+		if len(record) == 3 {
+			item.reference = record[2]
+		}
+
+		items = append(items, item)
+	}
+
+	return items, violations
+}
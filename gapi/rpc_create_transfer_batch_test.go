@@ -0,0 +1,334 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/worker"
+	mockwk "github.com/hoangtk0100/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requireFieldViolation checks that the status reports a violation on the field
+func requireFieldViolation(t *testing.T, st *status.Status, field string) {
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, violation := range badRequest.GetFieldViolations() {
+			if violation.GetField() == field {
+				return
+			}
+		}
+	}
+
+	require.Failf(t, "missing field violation", "no violation on field %s", field)
+}
+
+func TestCreateTransferBatch(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	user3, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account3 := randomAccount(user3.Username)
+	account2.Currency = account1.Currency
+	account3.Currency = account1.Currency
+
+	items := []*pb.TransferBatchItem{
+		{ToAccountId: account2.ID, Amount: 100, Reference: "PAY-001"},
+		{ToAccountId: account3.ID, Amount: 250},
+	}
+	rows := []db.CreateTransferBatchRowParams{
+		{RowNumber: 1, ToAccountID: account2.ID, Amount: 100, Reference: "PAY-001"},
+		{RowNumber: 2, ToAccountID: account3.ID, Amount: 250},
+	}
+	batch := db.TransferBatch{
+		ID:            1,
+		Owner:         user1.Username,
+		FromAccountID: account1.ID,
+		Status:        db.TransferBatchStatusPending,
+		RowCount:      2,
+		TotalAmount:   350,
+	}
+
+	buildContext := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+	}
+
+	createBatch := func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+		store.EXPECT().
+			CreateTransferBatchTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(ctx context.Context, arg db.CreateTransferBatchTxParams) (db.CreateTransferBatchTxResult, error) {
+				require.Equal(t, user1.Username, arg.Owner)
+				require.Equal(t, account1.ID, arg.FromAccountID)
+				require.Equal(t, rows, arg.Rows)
+				return db.CreateTransferBatchTxResult{Batch: batch}, arg.AfterCreate(batch)
+			})
+
+		taskPayload := &worker.PayloadProcessTransferBatch{
+			BatchID: batch.ID,
+		}
+		taskDistributor.EXPECT().
+			DistributeTaskProcessTransferBatch(gomock.Any(), taskPayload, gomock.Any()).
+			Times(1).
+			Return(nil)
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateTransferBatchRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateTransferBatchResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+				Items:         items,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+				createBatch(store, taskDistributor)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, batch.ID, res.GetBatch().GetId())
+				require.Equal(t, db.TransferBatchStatusPending, res.GetBatch().GetStatus())
+				require.Equal(t, int64(350), res.GetBatch().GetTotalAmount())
+			},
+		},
+		{
+			name: "CSV",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+				Csv:           fmt.Sprintf("to_account_id,amount,reference\n%d,100,PAY-001\n%d,250\n", account2.ID, account3.ID),
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+				createBatch(store, taskDistributor)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, batch.ID, res.GetBatch().GetId())
+			},
+		},
+		{
+			name: "InvalidCSV",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+				Csv:           fmt.Sprintf("%d,abc\n%d\n", account2.ID, account3.ID),
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "csv[1].amount")
+				requireFieldViolation(t, st, "csv[2]")
+			},
+		},
+		{
+			name: "ItemsAndCSV",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+				Items:         items,
+				Csv:           fmt.Sprintf("%d,100\n", account2.ID),
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "NoItems",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "InvalidItem",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+				Items: []*pb.TransferBatchItem{
+					items[0],
+					{ToAccountId: account1.ID, Amount: -1},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "items[1].to_account_id")
+				requireFieldViolation(t, st, "items[1].amount")
+			},
+		},
+		{
+			name: "ToAccountNotFound",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+				Items:         items,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(db.Account{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "items[0].to_account_id")
+			},
+		},
+		{
+			name: "ToAccountCurrencyMismatch",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+				Items:         items,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				otherAccount := account3
+				otherAccount.Currency = "OTHER"
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(otherAccount, nil)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "items[1].to_account_id")
+			},
+		},
+		{
+			name: "FromAccountNotOwned",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+				Items:         items,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, db.ErrRecordNotFound)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, user2.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.CreateTransferBatchRequest{
+				FromAccountId: account1.ID,
+				Currency:      account1.Currency,
+				Items:         items,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferBatchResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for index := range testCases {
+		tc := testCases[index]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+			server := newTestServer(t, store, taskDistributor)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CreateTransferBatch(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
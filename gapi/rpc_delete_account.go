@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeleteAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAuthorizedAccount(ctx, authPayload, req.GetId(), db.AccountPermissionClose)
+	if err != nil {
+		return nil, err
+	}
+
+	// Accounts are closed rather than deleted, so their entries and transfers are kept
+	account, err = server.updateAccountStatus(ctx, db.UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    db.AccountStatusClosed,
+		Reason:    "closed by the owner",
+		ChangedBy: authPayload.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.DeleteAccountResponse{
+		Account: convertAccount(account),
+	}
+	return rsp, nil
+}
+
+func validateDeleteAccountRequest(req *pb.DeleteAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolations("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,45 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeletePayee(ctx context.Context, req *pb.DeletePayeeRequest) (*pb.DeletePayeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeletePayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	payee, err := server.getOwnedPayee(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Transfers only record the accounts, so they are kept when the payee is removed
+	err = server.store.DeletePayee(ctx, payee.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete payee: %s", err)
+	}
+
+	return &pb.DeletePayeeResponse{}, nil
+}
+
+func validateDeletePayeeRequest(req *pb.DeletePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePayeeID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolations("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,82 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) Deposit(ctx context.Context, req *pb.DepositRequest) (*pb.DepositResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{util.BankerRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDepositRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.validAccount(ctx, "account_id", req.GetAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.DepositTxParams{
+		AccountID:         req.GetAccountId(),
+		Amount:            req.GetAmount(),
+		ReasonCode:        req.GetReasonCode(),
+		ExternalReference: req.GetExternalReference(),
+	}
+
+	result, err := server.store.DepositTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrAccountNotActive) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if errors.Is(err, db.ErrSystemAccount) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to deposit: %s", err)
+	}
+
+	rsp := &pb.DepositResponse{
+		Transfer: convertTransfer(result.Transfer),
+		Account:  convertAccount(result.Account),
+		Entry:    convertEntry(result.Entry),
+	}
+	return rsp, nil
+}
+
+func validateDepositRequest(req *pb.DepositRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolations("account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolations("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolations("currency", err))
+	}
+
+	if err := val.ValidateReasonCode(req.GetReasonCode()); err != nil {
+		violations = append(violations, fieldViolations("reason_code", err))
+	}
+
+	if req.GetExternalReference() != "" {
+		if err := val.ValidateExternalReference(req.GetExternalReference()); err != nil {
+			violations = append(violations, fieldViolations("external_reference", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,108 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/statement"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxStatementDays limits the period of a single statement so that exports stay small
+const maxStatementDays = 366
+
+var statementFormats = map[pb.StatementFormat]statement.Format{
+	pb.StatementFormat_STATEMENT_FORMAT_CSV: statement.FormatCSV,
+	pb.StatementFormat_STATEMENT_FORMAT_PDF: statement.FormatPDF,
+}
+
+func (server *Server) GetAccountStatement(ctx context.Context, req *pb.GetAccountStatementRequest) (*httpbody.HttpBody, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetAccountStatementRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAuthorizedAccount(ctx, authPayload, req.GetAccountId(), db.AccountPermissionView)
+	if err != nil {
+		return nil, err
+	}
+
+	// Already validated above
+	fromDate, _ := time.Parse(statement.DateLayout, req.GetFromDate())
+	toDate, _ := time.Parse(statement.DateLayout, req.GetToDate())
+
+	result, err := server.store.AccountStatementTx(ctx, db.AccountStatementTxParams{
+		AccountID: account.ID,
+		FromTime:  fromDate,
+		ToTime:    toDate.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account statement: %s", err)
+	}
+
+	cur, ok := server.currencies.Get(result.Account.Currency)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unknown currency %s", result.Account.Currency)
+	}
+
+	format := statementFormats[req.GetFormat()]
+	var buf bytes.Buffer
+	err = statement.New(result, cur, fromDate, toDate).Write(&buf, format)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to export account statement: %s", err)
+	}
+
+	rsp := &httpbody.HttpBody{
+		ContentType: statement.ContentType(format),
+		Data:        buf.Bytes(),
+	}
+	return rsp, nil
+}
+
+func validateGetAccountStatementRequest(req *pb.GetAccountStatementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolations("account_id", err))
+	}
+
+	fromDate, fromErr := time.Parse(statement.DateLayout, req.GetFromDate())
+	if fromErr != nil {
+		violations = append(violations, fieldViolations("from_date", fmt.Errorf("must be a date formatted as YYYY-MM-DD")))
+	}
+
+	toDate, toErr := time.Parse(statement.DateLayout, req.GetToDate())
+	if toErr != nil {
+		violations = append(violations, fieldViolations("to_date", fmt.Errorf("must be a date formatted as YYYY-MM-DD")))
+	}
+
+	if fromErr == nil && toErr == nil {
+		if toDate.Before(fromDate) {
+			violations = append(violations, fieldViolations("to_date", fmt.Errorf("must not be before from_date")))
+		} else if toDate.Sub(fromDate) >= maxStatementDays*24*time.Hour {
+			violations = append(violations, fieldViolations("to_date", fmt.Errorf("period must not exceed %d days", maxStatementDays)))
+		}
+	}
+
+	if _, ok := statementFormats[req.GetFormat()]; !ok {
+		violations = append(violations, fieldViolations("format", fmt.Errorf("must be CSV or PDF")))
+	}
+
+	return violations
+}
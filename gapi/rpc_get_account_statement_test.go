@@ -0,0 +1,217 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAccountStatement(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = util.USD
+
+	result := db.AccountStatementTxResult{
+		Account:        account,
+		OpeningBalance: 1000,
+		ClosingBalance: 750,
+		Entries: []db.ListStatementEntriesRow{
+			{
+				ID:        1,
+				Amount:    -250,
+				CreatedAt: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	validRequest := func(format pb.StatementFormat) *pb.GetAccountStatementRequest {
+		return &pb.GetAccountStatementRequest{
+			AccountId: account.ID,
+			FromDate:  "2026-03-01",
+			ToDate:    "2026-03-31",
+			Format:    format,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.GetAccountStatementRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *httpbody.HttpBody, err error)
+	}{
+		{
+			name: "CSV",
+			req:  validRequest(pb.StatementFormat_STATEMENT_FORMAT_CSV),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				// The last day is included in the period
+				arg := db.AccountStatementTxParams{
+					AccountID: account.ID,
+					FromTime:  time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+					ToTime:    time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+				}
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "text/csv", res.GetContentType())
+				require.Contains(t, string(res.GetData()), "Opening balance,10.00\n")
+				require.Contains(t, string(res.GetData()), "Closing balance,7.50\n")
+			},
+		},
+		{
+			name: "PDF",
+			req:  validRequest(pb.StatementFormat_STATEMENT_FORMAT_PDF),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(1).Return(result, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "application/pdf", res.GetContentType())
+				require.True(t, strings.HasPrefix(string(res.GetData()), "%PDF-"))
+			},
+		},
+		{
+			name: "BankerAccessOtherAccount",
+			req:  validRequest(pb.StatementFormat_STATEMENT_FORMAT_CSV),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(1).Return(result, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, otherUser.Username, util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			req:  validRequest(pb.StatementFormat_STATEMENT_FORMAT_CSV),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, db.ErrRecordNotFound)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, otherUser.Username, otherUser.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req:  validRequest(pb.StatementFormat_STATEMENT_FORMAT_CSV),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "InvalidPeriod",
+			req: &pb.GetAccountStatementRequest{
+				AccountId: account.ID,
+				FromDate:  "2026-03-31",
+				ToDate:    "2026-03-01",
+				Format:    pb.StatementFormat_STATEMENT_FORMAT_CSV,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "MissingFormat",
+			req:  validRequest(pb.StatementFormat_STATEMENT_FORMAT_UNSPECIFIED),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "InternalError",
+			req:  validRequest(pb.StatementFormat_STATEMENT_FORMAT_CSV),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountStatementTxResult{}, sql.ErrConnDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+	}
+
+	for index := range testCases {
+		tc := testCases[index]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.GetAccountStatement(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
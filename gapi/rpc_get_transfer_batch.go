@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetTransferBatch(ctx context.Context, req *pb.GetTransferBatchRequest) (*pb.GetTransferBatchResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetTransferBatchRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	batch, err := server.getOwnedTransferBatch(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := server.store.ListTransferBatchRows(ctx, batch.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfer batch rows: %s", err)
+	}
+
+	rsp := &pb.GetTransferBatchResponse{
+		Batch: convertTransferBatch(batch),
+		Rows:  make([]*pb.TransferBatchRow, 0, len(rows)),
+	}
+	for _, row := range rows {
+		rsp.Rows = append(rsp.Rows, convertTransferBatchRow(row))
+	}
+
+	return rsp, nil
+}
+
+func validateGetTransferBatchRequest(req *pb.GetTransferBatchRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateTransferBatchID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolations("id", err))
+	}
+
+	return violations
+}
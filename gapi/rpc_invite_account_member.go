@@ -0,0 +1,88 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) InviteAccountMember(ctx context.Context, req *pb.InviteAccountMemberRequest) (*pb.InviteAccountMemberResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateInviteAccountMemberRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAuthorizedAccount(ctx, authPayload, req.GetAccountId(), db.AccountPermissionManageMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Status == db.AccountStatusClosed {
+		return nil, status.Errorf(codes.FailedPrecondition, "account is closed")
+	}
+
+	// The invited user has no access to the account until the invitation is accepted
+	member, err := server.store.CreateAccountMember(ctx, db.CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  req.GetUsername(),
+		Role:      req.GetRole(),
+		SpendLimit: pgtype.Int8{
+			Int64: req.GetSpendLimit(),
+			Valid: req.GetRole() == db.AccountMemberRoleSpender,
+		},
+		Status:    db.AccountMemberStatusInvited,
+		InvitedBy: authPayload.Username,
+	})
+	if err != nil {
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			return nil, status.Errorf(codes.AlreadyExists, "user is already a member of the account")
+		case db.ForeignKeyViolation:
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create account member: %s", err)
+	}
+
+	rsp := &pb.InviteAccountMemberResponse{
+		Member: convertAccountMember(member),
+	}
+	return rsp, nil
+}
+
+func validateInviteAccountMemberRequest(req *pb.InviteAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolations("account_id", err))
+	}
+
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolations("username", err))
+	}
+
+	if err := val.ValidateAccountMemberRole(req.GetRole()); err != nil {
+		violations = append(violations, fieldViolations("role", err))
+	}
+
+	// Only a spender has a limit, the other roles can either spend freely or not at all
+	if req.GetRole() == db.AccountMemberRoleSpender {
+		if err := val.ValidateAmount(req.GetSpendLimit()); err != nil {
+			violations = append(violations, fieldViolations("spend_limit", err))
+		}
+	} else if req.GetSpendLimit() != 0 {
+		violations = append(violations, fieldViolations("spend_limit", fmt.Errorf("is only allowed for a spender")))
+	}
+
+	return violations
+}
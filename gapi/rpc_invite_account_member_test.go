@@ -0,0 +1,258 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// randomAccountMember returns an active member, the spend limit is only kept for a spender
+func randomAccountMember(accountID int64, username string, role string, spendLimit int64) db.AccountMember {
+	return db.AccountMember{
+		ID:        accountID,
+		AccountID: accountID,
+		Username:  username,
+		Role:      role,
+		SpendLimit: pgtype.Int8{
+			Int64: spendLimit,
+			Valid: role == db.AccountMemberRoleSpender,
+		},
+		Status:    db.AccountMemberStatusActive,
+		InvitedBy: username,
+		CreatedAt: time.Now(),
+		AcceptedAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+	}
+}
+
+func TestInviteAccountMember(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	user3, _ := randomUser(t)
+	account := randomAccount(user1.Username)
+	spendLimit := int64(100)
+
+	buildContext := func(t *testing.T, tokenMaker token.Maker) context.Context {
+		return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.InviteAccountMemberRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.InviteAccountMemberResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.InviteAccountMemberRequest{
+				AccountId:  account.ID,
+				Username:   user2.Username,
+				Role:       db.AccountMemberRoleSpender,
+				SpendLimit: spendLimit,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAccountMemberParams{
+					AccountID: account.ID,
+					Username:  user2.Username,
+					Role:      db.AccountMemberRoleSpender,
+					SpendLimit: pgtype.Int8{
+						Int64: spendLimit,
+						Valid: true,
+					},
+					Status:    db.AccountMemberStatusInvited,
+					InvitedBy: user1.Username,
+				}
+
+				member := randomAccountMember(account.ID, user2.Username, db.AccountMemberRoleSpender, spendLimit)
+				member.Status = db.AccountMemberStatusInvited
+				member.InvitedBy = user1.Username
+				member.AcceptedAt = pgtype.Timestamptz{}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAccountMember(gomock.Any(), gomock.Eq(arg)).Times(1).Return(member, nil)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user2.Username, res.GetMember().GetUsername())
+				require.Equal(t, db.AccountMemberStatusInvited, res.GetMember().GetStatus())
+				require.Equal(t, spendLimit, res.GetMember().GetSpendLimit())
+				require.Nil(t, res.GetMember().GetAcceptedAt())
+			},
+		},
+		{
+			name: "CoOwnerInvites",
+			req: &pb.InviteAccountMemberRequest{
+				AccountId: account.ID,
+				Username:  user3.Username,
+				Role:      db.AccountMemberRoleViewer,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: account.ID, Username: user2.Username})).
+					Times(1).
+					Return(randomAccountMember(account.ID, user2.Username, db.AccountMemberRoleCoOwner, 0), nil)
+				store.EXPECT().
+					CreateAccountMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomAccountMember(account.ID, user3.Username, db.AccountMemberRoleViewer, 0), nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, user2.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.AccountMemberRoleViewer, res.GetMember().GetRole())
+			},
+		},
+		{
+			name: "SpenderCanNotInvite",
+			req: &pb.InviteAccountMemberRequest{
+				AccountId: account.ID,
+				Username:  user3.Username,
+				Role:      db.AccountMemberRoleViewer,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					GetAccountMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomAccountMember(account.ID, user2.Username, db.AccountMemberRoleSpender, spendLimit), nil)
+				store.EXPECT().CreateAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, user2.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "AlreadyMember",
+			req: &pb.InviteAccountMemberRequest{
+				AccountId: account.ID,
+				Username:  user2.Username,
+				Role:      db.AccountMemberRoleCoOwner,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreateAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, db.ErrUniqueViolation)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.AlreadyExists, st.Code())
+			},
+		},
+		{
+			name: "ClosedAccount",
+			req: &pb.InviteAccountMemberRequest{
+				AccountId: account.ID,
+				Username:  user2.Username,
+				Role:      db.AccountMemberRoleViewer,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				closed := account
+				closed.Status = db.AccountStatusClosed
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(closed, nil)
+				store.EXPECT().CreateAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "InvalidRole",
+			req: &pb.InviteAccountMemberRequest{
+				AccountId: account.ID,
+				Username:  user2.Username,
+				Role:      db.AccountMemberRoleOwner,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "role")
+			},
+		},
+		{
+			name: "MissingSpendLimit",
+			req: &pb.InviteAccountMemberRequest{
+				AccountId: account.ID,
+				Username:  user2.Username,
+				Role:      db.AccountMemberRoleSpender,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "spend_limit")
+			},
+		},
+		{
+			name: "SpendLimitForViewer",
+			req: &pb.InviteAccountMemberRequest{
+				AccountId:  account.ID,
+				Username:   user2.Username,
+				Role:       db.AccountMemberRoleViewer,
+				SpendLimit: spendLimit,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: buildContext,
+			checkResponse: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				requireFieldViolation(t, st, "spend_limit")
+			},
+		},
+	}
+
+	for index := range testCases {
+		tc := testCases[index]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.InviteAccountMember(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccountInvitations(ctx context.Context, req *pb.ListAccountInvitationsRequest) (*pb.ListAccountInvitationsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	invitations, err := server.store.ListAccountInvitations(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account invitations: %s", err)
+	}
+
+	rsp := &pb.ListAccountInvitationsResponse{
+		Invitations: make([]*pb.AccountMember, 0, len(invitations)),
+	}
+	for _, invitation := range invitations {
+		rsp.Invitations = append(rsp.Invitations, convertAccountMember(invitation))
+	}
+
+	return rsp, nil
+}
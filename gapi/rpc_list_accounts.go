@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.ListAccountsParams{
+		Owner: authPayload.Username,
+		Limit: req.GetPageSize(),
+	}
+
+	// page_id is deprecated, it keeps the offset pagination working until clients move to page_token
+	if req.GetPageId() > 0 {
+		arg.Offset = (req.GetPageId() - 1) * req.GetPageSize()
+	} else {
+		arg.AfterID, _ = util.DecodePageToken(req.GetPageToken())
+	}
+
+	accounts, err := server.store.ListAccounts(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list accounts: %s", err)
+	}
+
+	rsp := &pb.ListAccountsResponse{
+		Accounts: make([]*pb.Account, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		rsp.Accounts = append(rsp.Accounts, convertAccount(account))
+	}
+
+	if req.GetPageId() == 0 && len(accounts) > 0 {
+		rsp.NextPageToken = util.NextPageToken(accounts[len(accounts)-1].ID, len(accounts), req.GetPageSize())
+	}
+	return rsp, nil
+}
+
+func validateListAccountsRequest(req *pb.ListAccountsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetPageId() != 0 {
+		if err := val.ValidatePageID(req.GetPageId()); err != nil {
+			violations = append(violations, fieldViolations("page_id", err))
+		}
+
+		if req.GetPageToken() != "" {
+			violations = append(violations, fieldViolations("page_token", fmt.Errorf("cannot be combined with page_id")))
+		}
+	} else if err := val.ValidatePageToken(req.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolations("page_token", err))
+	}
+
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolations("page_size", err))
+	}
+
+	return violations
+}
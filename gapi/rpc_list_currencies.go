@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListCurrencies(ctx context.Context, req *pb.ListCurrenciesRequest) (*pb.ListCurrenciesResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	currencies, err := server.store.ListCurrencies(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list currencies: %s", err)
+	}
+
+	rsp := &pb.ListCurrenciesResponse{
+		Currencies: make([]*pb.Currency, 0, len(currencies)),
+	}
+	for _, currency := range currencies {
+		rsp.Currencies = append(rsp.Currencies, convertCurrency(currency))
+	}
+	return rsp, nil
+}
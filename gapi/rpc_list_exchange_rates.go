@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListExchangeRates(ctx context.Context, req *pb.ListExchangeRatesRequest) (*pb.ListExchangeRatesResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	rates, err := server.store.ListExchangeRates(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list exchange rates: %s", err)
+	}
+
+	rsp := &pb.ListExchangeRatesResponse{
+		ExchangeRates: make([]*pb.ExchangeRate, 0, len(rates)),
+	}
+	for _, rate := range rates {
+		rsp.ExchangeRates = append(rsp.ExchangeRates, convertExchangeRate(rate))
+	}
+	return rsp, nil
+}
@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListFeeSchedules(ctx context.Context, req *pb.ListFeeSchedulesRequest) (*pb.ListFeeSchedulesResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	schedules, err := server.store.ListFeeSchedules(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list fee schedules: %s", err)
+	}
+
+	rsp := &pb.ListFeeSchedulesResponse{
+		FeeSchedules: make([]*pb.FeeSchedule, 0, len(schedules)),
+	}
+	for _, schedule := range schedules {
+		rsp.FeeSchedules = append(rsp.FeeSchedules, convertFeeSchedule(schedule))
+	}
+	return rsp, nil
+}
@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListPayees(ctx context.Context, req *pb.ListPayeesRequest) (*pb.ListPayeesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListPayeesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// Already validated above
+	afterID, _ := util.DecodePageToken(req.GetPageToken())
+	arg := db.ListPayeesParams{
+		Owner:   authPayload.Username,
+		AfterID: afterID,
+		Limit:   req.GetPageSize(),
+	}
+
+	payees, err := server.store.ListPayees(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payees: %s", err)
+	}
+
+	rsp := &pb.ListPayeesResponse{
+		Payees: make([]*pb.Payee, 0, len(payees)),
+	}
+	for _, payee := range payees {
+		rsp.Payees = append(rsp.Payees, convertPayee(payee))
+	}
+
+	if len(payees) > 0 {
+		rsp.NextPageToken = util.NextPageToken(payees[len(payees)-1].ID, len(payees), req.GetPageSize())
+	}
+	return rsp, nil
+}
+
+func validateListPayeesRequest(req *pb.ListPayeesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolations("page_size", err))
+	}
+
+	if err := val.ValidatePageToken(req.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolations("page_token", err))
+	}
+
+	return violations
+}
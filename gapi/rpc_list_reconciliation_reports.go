@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListReconciliationReports(ctx context.Context, req *pb.ListReconciliationReportsRequest) (*pb.ListReconciliationReportsResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{util.BankerRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListReconciliationReportsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// Already validated above
+	afterID, _ := util.DecodePageToken(req.GetPageToken())
+	arg := db.ListReconciliationReportsParams{
+		AfterID: afterID,
+		Limit:   req.GetPageSize(),
+	}
+
+	reports, err := server.store.ListReconciliationReports(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list reconciliation reports: %s", err)
+	}
+
+	rsp := &pb.ListReconciliationReportsResponse{
+		Reports: make([]*pb.ReconciliationReport, 0, len(reports)),
+	}
+	for _, report := range reports {
+		rsp.Reports = append(rsp.Reports, convertReconciliationReport(report))
+	}
+
+	if len(reports) > 0 {
+		rsp.NextPageToken = util.NextPageToken(reports[len(reports)-1].ID, len(reports), req.GetPageSize())
+	}
+	return rsp, nil
+}
+
+func validateListReconciliationReportsRequest(req *pb.ListReconciliationReportsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolations("page_size", err))
+	}
+
+	if err := val.ValidatePageToken(req.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolations("page_token", err))
+	}
+
+	return violations
+}
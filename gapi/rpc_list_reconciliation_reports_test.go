@@ -0,0 +1,131 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListReconciliationReports(t *testing.T) {
+	banker, _ := randomUser(t)
+	banker.Role = util.BankerRole
+	depositor, _ := randomUser(t)
+
+	n := 5
+	reports := make([]db.ReconciliationReport, n)
+	for i := 0; i < n; i++ {
+		reports[i] = db.ReconciliationReport{
+			ID:           int64(i + 1),
+			AccountID:    util.RandomInt(1, 1000),
+			Balance:      100,
+			EntriesTotal: 90,
+			Difference:   10,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListReconciliationReportsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ListReconciliationReportsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ListReconciliationReportsRequest{
+				PageSize: int32(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListReconciliationReportsParams{
+					Limit: int32(n),
+				}
+
+				store.EXPECT().
+					ListReconciliationReports(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(reports, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListReconciliationReportsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetReports(), n)
+				for i, report := range res.GetReports() {
+					require.Equal(t, reports[i].ID, report.GetId())
+					require.Equal(t, reports[i].AccountID, report.GetAccountId())
+					require.Equal(t, reports[i].Difference, report.GetDifference())
+				}
+				require.Equal(t, util.EncodePageToken(reports[n-1].ID), res.GetNextPageToken())
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req: &pb.ListReconciliationReportsRequest{
+				PageSize: int32(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListReconciliationReports(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, depositor.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListReconciliationReportsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "InvalidPageToken",
+			req: &pb.ListReconciliationReportsRequest{
+				PageSize:  int32(n),
+				PageToken: "invalid",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListReconciliationReports(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListReconciliationReportsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for index := range testCases {
+		tc := testCases[index]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.ListReconciliationReports(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListRiskDecisions(ctx context.Context, req *pb.ListRiskDecisionsRequest) (*pb.ListRiskDecisionsResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{util.BankerRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListRiskDecisionsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// Already validated above
+	afterID, _ := util.DecodePageToken(req.GetPageToken())
+	arg := db.ListRiskDecisionsParams{
+		PendingReview: req.GetPendingReview(),
+		AfterID:       afterID,
+		Limit:         req.GetPageSize(),
+	}
+
+	decisions, err := server.store.ListRiskDecisions(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list risk decisions: %s", err)
+	}
+
+	rsp := &pb.ListRiskDecisionsResponse{
+		Decisions: make([]*pb.RiskDecision, 0, len(decisions)),
+	}
+	for _, decision := range decisions {
+		rsp.Decisions = append(rsp.Decisions, convertRiskDecision(decision))
+	}
+
+	if len(decisions) > 0 {
+		rsp.NextPageToken = util.NextPageToken(decisions[len(decisions)-1].ID, len(decisions), req.GetPageSize())
+	}
+	return rsp, nil
+}
+
+func validateListRiskDecisionsRequest(req *pb.ListRiskDecisionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolations("page_size", err))
+	}
+
+	if err := val.ValidatePageToken(req.GetPageToken()); err != nil {
+		violations = append(violations, fieldViolations("page_token", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RemoveAccountMember(ctx context.Context, req *pb.RemoveAccountMemberRequest) (*pb.RemoveAccountMemberResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRemoveAccountMemberRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// Users can always leave an account or decline an invitation, removing someone else needs to manage the members
+	if req.GetUsername() != authPayload.Username {
+		_, err = server.getAuthorizedAccount(ctx, authPayload, req.GetAccountId(), db.AccountPermissionManageMembers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	member, err := server.store.GetAccountMember(ctx, db.GetAccountMemberParams{
+		AccountID: req.GetAccountId(),
+		Username:  req.GetUsername(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account member not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account member: %s", err)
+	}
+
+	if member.Role == db.AccountMemberRoleOwner {
+		return nil, status.Errorf(codes.FailedPrecondition, "the owner can not be removed from the account")
+	}
+
+	err = server.store.DeleteAccountMember(ctx, db.DeleteAccountMemberParams{
+		AccountID: member.AccountID,
+		Username:  member.Username,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete account member: %s", err)
+	}
+
+	return &pb.RemoveAccountMemberResponse{}, nil
+}
+
+func validateRemoveAccountMemberRequest(req *pb.RemoveAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolations("account_id", err))
+	}
+
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolations("username", err))
+	}
+
+	return violations
+}
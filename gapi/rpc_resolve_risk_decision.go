@@ -0,0 +1,70 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ResolveRiskDecision(ctx context.Context, req *pb.ResolveRiskDecisionRequest) (*pb.ResolveRiskDecisionResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateResolveRiskDecisionRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ResolveRiskDecisionTx(ctx, db.ResolveRiskDecisionTxParams{
+		ID:         req.GetId(),
+		Approve:    req.GetApprove(),
+		ReviewedBy: authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "risk decision not found")
+		}
+		if errors.Is(err, db.ErrRiskDecisionNotPending) ||
+			errors.Is(err, db.ErrHoldNotPending) ||
+			errors.Is(err, db.ErrInsufficientFunds) ||
+			errors.Is(err, db.ErrAccountNotActive) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if errors.Is(err, db.ErrSystemAccount) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s", err)
+		}
+		if errors.Is(err, db.ErrTransferLimitExceeded) {
+			return nil, status.Errorf(codes.ResourceExhausted, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to resolve risk decision: %s", err)
+	}
+
+	rsp := &pb.ResolveRiskDecisionResponse{
+		Decision: convertRiskDecision(result.RiskDecision),
+	}
+	if req.GetApprove() {
+		rsp.Transfer = convertTransfer(result.Transfer.Transfer)
+		rsp.FromAccount = convertAccount(result.Transfer.FromAccount)
+		rsp.ToAccount = convertAccount(result.Transfer.ToAccount)
+		rsp.FromEntry = convertEntry(result.Transfer.FromEntry)
+		rsp.ToEntry = convertEntry(result.Transfer.ToEntry)
+	}
+	return rsp, nil
+}
+
+func validateResolveRiskDecisionRequest(req *pb.ResolveRiskDecisionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateRiskDecisionID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolations("id", err))
+	}
+
+	return violations
+}
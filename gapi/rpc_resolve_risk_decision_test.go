@@ -0,0 +1,223 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolveRiskDecision(t *testing.T) {
+	banker, _ := randomUser(t)
+	banker.Role = util.BankerRole
+	depositor, _ := randomUser(t)
+
+	account1 := randomAccount(depositor.Username)
+	account2 := randomAccount(depositor.Username)
+	decision := db.RiskDecision{
+		ID:            util.RandomInt(1, 1000),
+		Username:      depositor.Username,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		Decision:      db.RiskDecisionReview,
+		Rules:         []string{"new_payee"},
+		HoldID: pgtype.Int8{
+			Int64: util.RandomInt(1, 1000),
+			Valid: true,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ResolveRiskDecisionRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ResolveRiskDecisionResponse, err error)
+	}{
+		{
+			name: "Approve",
+			req: &pb.ResolveRiskDecisionRequest{
+				Id:      decision.ID,
+				Approve: true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ResolveRiskDecisionTxParams{
+					ID:         decision.ID,
+					Approve:    true,
+					ReviewedBy: banker.Username,
+				}
+
+				transfer := db.Transfer{
+					ID:            util.RandomInt(1, 1000),
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        decision.Amount,
+					ToAmount:      decision.Amount,
+				}
+				resolved := decision
+				resolved.Resolution = pgtype.Text{String: db.RiskResolutionApproved, Valid: true}
+				resolved.TransferID = pgtype.Int8{Int64: transfer.ID, Valid: true}
+
+				store.EXPECT().
+					ResolveRiskDecisionTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ResolveRiskDecisionTxResult{
+						RiskDecision: resolved,
+						Transfer: db.TransferTxResult{
+							Transfer:    transfer,
+							FromAccount: account1,
+							ToAccount:   account2,
+						},
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResolveRiskDecisionResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.RiskResolutionApproved, res.GetDecision().GetResolution())
+				require.Equal(t, res.GetTransfer().GetId(), res.GetDecision().GetTransferId())
+				require.Equal(t, account1.ID, res.GetFromAccount().GetId())
+				require.Equal(t, account2.ID, res.GetToAccount().GetId())
+			},
+		},
+		{
+			name: "Reject",
+			req: &pb.ResolveRiskDecisionRequest{
+				Id: decision.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				resolved := decision
+				resolved.Resolution = pgtype.Text{String: db.RiskResolutionRejected, Valid: true}
+
+				store.EXPECT().
+					ResolveRiskDecisionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ResolveRiskDecisionTxResult{
+						RiskDecision: resolved,
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResolveRiskDecisionResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.RiskResolutionRejected, res.GetDecision().GetResolution())
+				require.Nil(t, res.GetTransfer())
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req: &pb.ResolveRiskDecisionRequest{
+				Id:      decision.ID,
+				Approve: true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResolveRiskDecisionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, depositor.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResolveRiskDecisionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "InvalidID",
+			req: &pb.ResolveRiskDecisionRequest{
+				Id: 0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResolveRiskDecisionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResolveRiskDecisionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "AlreadyResolved",
+			req: &pb.ResolveRiskDecisionRequest{
+				Id:      decision.ID,
+				Approve: true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResolveRiskDecisionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ResolveRiskDecisionTxResult{}, db.ErrRiskDecisionNotPending)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResolveRiskDecisionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.ResolveRiskDecisionRequest{
+				Id:      decision.ID,
+				Approve: true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResolveRiskDecisionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ResolveRiskDecisionTxResult{}, db.ErrRecordNotFound)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResolveRiskDecisionResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+	}
+
+	for index := range testCases {
+		tc := testCases[index]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.ResolveRiskDecision(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
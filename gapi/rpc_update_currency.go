@@ -0,0 +1,70 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hoangtk0100/simple-bank/currency"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateCurrency never changes the exponent, the balances already stored depend on it
+func (server *Server) UpdateCurrency(ctx context.Context, req *pb.UpdateCurrencyRequest) (*pb.UpdateCurrencyResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{util.BankerRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateCurrencyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.UpdateCurrencyParams{
+		Code: req.GetCode(),
+		Symbol: pgtype.Text{
+			String: req.GetSymbol(),
+			Valid:  req.Symbol != nil,
+		},
+		Enabled: pgtype.Bool{
+			Bool:  req.GetEnabled(),
+			Valid: req.Enabled != nil,
+		},
+	}
+
+	result, err := server.store.UpdateCurrency(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "currency not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update currency: %s", err)
+	}
+
+	server.currencies.Set(currency.FromDB(result))
+
+	rsp := &pb.UpdateCurrencyResponse{
+		Currency: convertCurrency(result),
+	}
+	return rsp, nil
+}
+
+func validateUpdateCurrencyRequest(req *pb.UpdateCurrencyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrencyCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolations("code", err))
+	}
+
+	if req.Symbol != nil {
+		if err := val.ValidateCurrencySymbol(req.GetSymbol()); err != nil {
+			violations = append(violations, fieldViolations("symbol", err))
+		}
+	}
+
+	return violations
+}
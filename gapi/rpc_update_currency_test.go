@@ -0,0 +1,181 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hoangtk0100/simple-bank/currency"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/token"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateCurrency(t *testing.T) {
+	banker, _ := randomUser(t)
+	banker.Role = util.BankerRole
+	depositor, _ := randomUser(t)
+
+	usd := currency.Currency{Code: util.USD, NumericCode: 840, Exponent: 2, Symbol: "$", Enabled: true}
+	disabled := false
+
+	testCases := []struct {
+		name          string
+		req           *pb.UpdateCurrencyRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.UpdateCurrencyResponse, err error, registry *currency.Registry)
+	}{
+		{
+			name: "OK",
+			req: &pb.UpdateCurrencyRequest{
+				Code:    util.USD,
+				Enabled: &disabled,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateCurrencyParams{
+					Code: util.USD,
+					Enabled: pgtype.Bool{
+						Bool:  false,
+						Valid: true,
+					},
+				}
+
+				updatedCurrency := db.Currency{
+					Code:        usd.Code,
+					NumericCode: usd.NumericCode,
+					Exponent:    usd.Exponent,
+					Symbol:      usd.Symbol,
+					Enabled:     false,
+				}
+
+				store.EXPECT().
+					UpdateCurrency(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updatedCurrency, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error, registry *currency.Registry) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Equal(t, util.USD, res.GetCurrency().GetCode())
+				require.False(t, res.GetCurrency().GetEnabled())
+
+				// Validators stop accepting the currency right away
+				require.False(t, registry.IsSupported(util.USD))
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.UpdateCurrencyRequest{
+				Code:    "XYZ",
+				Enabled: &disabled,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateCurrency(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Currency{}, db.ErrRecordNotFound)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error, registry *currency.Registry) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InternalError",
+			req: &pb.UpdateCurrencyRequest{
+				Code:    util.USD,
+				Enabled: &disabled,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateCurrency(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Currency{}, sql.ErrConnDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error, registry *currency.Registry) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+				require.True(t, registry.IsSupported(util.USD))
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req: &pb.UpdateCurrencyRequest{
+				Code:    util.USD,
+				Enabled: &disabled,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, depositor.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error, registry *currency.Registry) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "InvalidCode",
+			req: &pb.UpdateCurrencyRequest{
+				Code:    "usd",
+				Enabled: &disabled,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error, registry *currency.Registry) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for index := range testCases {
+		tc := testCases[index]
+
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+			// Keep the process-wide registry untouched
+			server.currencies = currency.NewRegistry(usd)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.UpdateCurrency(ctx, tc.req)
+			tc.checkResponse(t, res, err, server.currencies)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/fx"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateExchangeRate(ctx context.Context, req *pb.UpdateExchangeRateRequest) (*pb.UpdateExchangeRateResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateExchangeRateRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// Already validated above
+	rate, _ := fx.ParseRate(req.GetRate())
+
+	exchangeRate, err := server.store.UpsertExchangeRate(ctx, db.UpsertExchangeRateParams{
+		FromCurrency: req.GetFromCurrency(),
+		ToCurrency:   req.GetToCurrency(),
+		Rate:         rate,
+		UpdatedBy:    authPayload.Username,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update exchange rate: %s", err)
+	}
+
+	rsp := &pb.UpdateExchangeRateResponse{
+		ExchangeRate: convertExchangeRate(exchangeRate),
+	}
+	return rsp, nil
+}
+
+func validateUpdateExchangeRateRequest(req *pb.UpdateExchangeRateRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrency(req.GetFromCurrency()); err != nil {
+		violations = append(violations, fieldViolations("from_currency", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetToCurrency()); err != nil {
+		violations = append(violations, fieldViolations("to_currency", err))
+	}
+
+	if req.GetFromCurrency() == req.GetToCurrency() {
+		violations = append(violations, fieldViolations("to_currency", fmt.Errorf("must be different from from_currency")))
+	}
+
+	if _, err := fx.ParseRate(req.GetRate()); err != nil {
+		violations = append(violations, fieldViolations("rate", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateFeeSchedule(ctx context.Context, req *pb.UpdateFeeScheduleRequest) (*pb.UpdateFeeScheduleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateFeeScheduleRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	schedule, err := server.store.UpsertFeeSchedule(ctx, db.UpsertFeeScheduleParams{
+		Product:         req.GetProduct(),
+		Currency:        req.GetCurrency(),
+		MonthlyFee:      req.GetMonthlyFee(),
+		TransferFee:     req.GetTransferFee(),
+		MinimumBalance:  req.GetMinimumBalance(),
+		BelowMinimumFee: req.GetBelowMinimumFee(),
+		UpdatedBy:       authPayload.Username,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			return nil, status.Errorf(codes.NotFound, "account product not found: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update fee schedule: %s", err)
+	}
+
+	rsp := &pb.UpdateFeeScheduleResponse{
+		FeeSchedule: convertFeeSchedule(schedule),
+	}
+	return rsp, nil
+}
+
+func validateUpdateFeeScheduleRequest(req *pb.UpdateFeeScheduleRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateProductCode(req.GetProduct()); err != nil {
+		violations = append(violations, fieldViolations("product", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolations("currency", err))
+	}
+
+	if err := val.ValidateNonNegativeAmount(req.GetMonthlyFee()); err != nil {
+		violations = append(violations, fieldViolations("monthly_fee", err))
+	}
+
+	if err := val.ValidateNonNegativeAmount(req.GetTransferFee()); err != nil {
+		violations = append(violations, fieldViolations("transfer_fee", err))
+	}
+
+	if err := val.ValidateNonNegativeAmount(req.GetMinimumBalance()); err != nil {
+		violations = append(violations, fieldViolations("minimum_balance", err))
+	}
+
+	if err := val.ValidateNonNegativeAmount(req.GetBelowMinimumFee()); err != nil {
+		violations = append(violations, fieldViolations("below_minimum_fee", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdatePayee(ctx context.Context, req *pb.UpdatePayeeRequest) (*pb.UpdatePayeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdatePayeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	payee, err := server.getOwnedPayee(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	payee, err = server.store.UpdatePayee(ctx, db.UpdatePayeeParams{
+		ID: payee.ID,
+		Nickname: pgtype.Text{
+			String: req.GetNickname(),
+			Valid:  req.Nickname != nil,
+		},
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "payee nickname already exists: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update payee: %s", err)
+	}
+
+	rsp := &pb.UpdatePayeeResponse{
+		Payee: convertPayee(payee),
+	}
+	return rsp, nil
+}
+
+func validateUpdatePayeeRequest(req *pb.UpdatePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePayeeID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolations("id", err))
+	}
+
+	if req.Nickname != nil {
+		if err := val.ValidatePayeeNickname(req.GetNickname()); err != nil {
+			violations = append(violations, fieldViolations("nickname", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,107 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/hoangtk0100/simple-bank/val"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateScheduledTransfer(ctx context.Context, req *pb.UpdateScheduledTransferRequest) (*pb.UpdateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.getOwnedScheduledTransfer(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduledTransfer.Status != db.ScheduledTransferStatusActive {
+		return nil, status.Errorf(codes.FailedPrecondition, "scheduled transfer is %s", scheduledTransfer.Status)
+	}
+
+	// The owner may be a member of the from account, whose spend limit also caps the new amount
+	if req.Amount != nil {
+		fromAccount, err := server.getAccount(ctx, scheduledTransfer.FromAccountID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = server.authorizeAccount(ctx, authPayload, fromAccount, db.AccountPermissionSpend, req.GetAmount())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	arg := db.UpdateScheduledTransferParams{
+		ID: scheduledTransfer.ID,
+		Amount: pgtype.Int8{
+			Int64: req.GetAmount(),
+			Valid: req.Amount != nil,
+		},
+		Recurrence: pgtype.Text{
+			String: req.GetRecurrence(),
+			Valid:  req.Recurrence != nil,
+		},
+		NextRunAt: pgtype.Timestamptz{
+			Time:  req.GetNextRunAt().AsTime(),
+			Valid: req.NextRunAt != nil,
+		},
+	}
+
+	if req.Recurrence != nil && req.NextRunAt == nil {
+		// Already validated above
+		arg.NextRunAt.Time, _ = util.NextRecurrence(req.GetRecurrence(), time.Now())
+		arg.NextRunAt.Valid = true
+	}
+
+	scheduledTransfer, err = server.store.UpdateScheduledTransfer(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update scheduled transfer: %s", err)
+	}
+
+	rsp := &pb.UpdateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+	return rsp, nil
+}
+
+func validateUpdateScheduledTransferRequest(req *pb.UpdateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateScheduledTransferID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolations("id", err))
+	}
+
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolations("amount", err))
+		}
+	}
+
+	if req.Recurrence != nil {
+		if err := val.ValidateRecurrence(req.GetRecurrence()); err != nil {
+			violations = append(violations, fieldViolations("recurrence", err))
+		}
+	}
+
+	if req.NextRunAt != nil {
+		if err := val.ValidateFutureTime(req.GetNextRunAt().AsTime()); err != nil {
+			violations = append(violations, fieldViolations("next_run_at", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getOwnedScheduledTransfer loads the scheduled transfer and makes sure it belongs to the authenticated user.
+// Scheduled transfers move the money of their owner, so bankers can not manage them on behalf of the owner.
+func (server *Server) getOwnedScheduledTransfer(ctx context.Context, authPayload *token.Payload, id int64) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := server.store.GetScheduledTransfer(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return scheduledTransfer, status.Errorf(codes.NotFound, "scheduled transfer not found")
+		}
+		return scheduledTransfer, status.Errorf(codes.Internal, "failed to get scheduled transfer: %s", err)
+	}
+
+	if scheduledTransfer.Owner != authPayload.Username {
+		return scheduledTransfer, status.Errorf(codes.PermissionDenied, "the scheduled transfer doesn't belong to the authenticated user")
+	}
+
+	return scheduledTransfer, nil
+}
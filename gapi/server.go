@@ -3,8 +3,11 @@ package gapi
 import (
 	"fmt"
 
+	"github.com/hoangtk0100/simple-bank/currency"
 	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/fx"
 	"github.com/hoangtk0100/simple-bank/pb"
+	"github.com/hoangtk0100/simple-bank/risk"
 	"github.com/hoangtk0100/simple-bank/token"
 	"github.com/hoangtk0100/simple-bank/util"
 	"github.com/hoangtk0100/simple-bank/worker"
@@ -17,6 +20,9 @@ type Server struct {
 	store           db.Store
 	tokenMaker      token.Maker
 	taskDistributor worker.TaskDistributor
+	rateProvider    fx.RateProvider
+	currencies      *currency.Registry
+	riskEngine      *risk.Engine
 }
 
 // NewServer creates a new gRPC server
@@ -31,6 +37,9 @@ func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDi
 		store:           store,
 		tokenMaker:      tokenMaker,
 		taskDistributor: taskDistributor,
+		rateProvider:    fx.NewStoreRateProvider(store),
+		currencies:      currency.Default(),
+		riskEngine:      risk.NewEngine(risk.DefaultRules(store, config)...),
 	}
 
 	return server, nil
@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getOwnedTransferBatch loads the transfer batch and makes sure it belongs to the authenticated user.
+func (server *Server) getOwnedTransferBatch(ctx context.Context, authPayload *token.Payload, id int64) (db.TransferBatch, error) {
+	batch, err := server.store.GetTransferBatch(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return batch, status.Errorf(codes.NotFound, "transfer batch not found")
+		}
+		return batch, status.Errorf(codes.Internal, "failed to get transfer batch: %s", err)
+	}
+
+	if batch.Owner != authPayload.Username {
+		return batch, status.Errorf(codes.PermissionDenied, "the transfer batch doesn't belong to the authenticated user")
+	}
+
+	return batch, nil
+}
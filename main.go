@@ -15,6 +15,7 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/hoangtk0100/simple-bank/api"
+	"github.com/hoangtk0100/simple-bank/currency"
 	db "github.com/hoangtk0100/simple-bank/db/sqlc"
 	_ "github.com/hoangtk0100/simple-bank/docs/statik"
 	"github.com/hoangtk0100/simple-bank/gapi"
@@ -48,6 +49,14 @@ func main() {
 
 	store := db.NewStore(connPool)
 
+	// Validators of both servers read the supported currencies from the shared registry
+	err = currency.Default().Load(context.Background(), store)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load currencies")
+	}
+
+	go currency.Default().Refresh(context.Background(), store, config.CurrencyRefreshInterval)
+
 	redisOpt := asynq.RedisClientOpt{
 		Addr:     config.RedisAddress,
 		Password: config.RedisPassword,
@@ -57,7 +66,8 @@ func main() {
 
 	// runTaskProcessor in a separate go routine
 	// Because when the processor starts, the Asynq server will block and keep polling Redis for new tasks
-	go runTaskProcessor(config, redisOpt, store)
+	go runTaskProcessor(config, redisOpt, store, taskDistributor)
+	runTaskScheduler(config, redisOpt)
 
 	// Can not call both runGrpcServer, runGinServer for serving both GRPC and HTTP requests in the same go routine
 	// so run 1 of them in the separate go routine, not blocking each other from starting
@@ -78,9 +88,9 @@ func runDBMigration(migrationURL string, dbSource string) {
 	log.Info().Msg("db migrated successfully")
 }
 
-func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store) {
+func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store, taskDistributor worker.TaskDistributor) {
 	mailer := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer, taskDistributor)
 	log.Info().Msg("start task processor")
 
 	err := taskProcessor.Start()
@@ -89,6 +99,23 @@ func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store d
 	}
 }
 
+func runTaskScheduler(config util.Config, redisOpt asynq.RedisClientOpt) {
+	taskScheduler := worker.NewRedisTaskScheduler(redisOpt, worker.ScheduleConfig{
+		ReconcileAccounts:          config.ReconcileAccountsSchedule,
+		ExpireHolds:                config.ExpireHoldsSchedule,
+		DispatchScheduledTransfers: config.ScheduledTransfersSchedule,
+		AccrueInterest:             config.AccrueInterestSchedule,
+		PostInterest:               config.PostInterestSchedule,
+		ChargeMaintenanceFees:      config.MaintenanceFeesSchedule,
+	})
+	log.Info().Msg("start task scheduler")
+
+	err := taskScheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start task scheduler")
+	}
+}
+
 // runGatewayServer: Set up HTTP gateway with in-process translation method
 func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
 	server, err := gapi.NewServer(config, store, taskDistributor)
@@ -106,7 +133,10 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
+	// Forward the Idempotency-Key header to the gRPC handlers
+	headerMatcher := runtime.WithIncomingHeaderMatcher(gapi.IncomingHeaderMatcher)
+
+	grpcMux := runtime.NewServeMux(jsonOption, headerMatcher)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	// only executed before exiting this runGatewayServer function
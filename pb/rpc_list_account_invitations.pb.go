@@ -0,0 +1,205 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.24.4
+// source: rpc_list_account_invitations.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListAccountInvitationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListAccountInvitationsRequest) Reset() {
+	*x = ListAccountInvitationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_account_invitations_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAccountInvitationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountInvitationsRequest) ProtoMessage() {}
+
+func (x *ListAccountInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_account_invitations_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_account_invitations_proto_rawDescGZIP(), []int{0}
+}
+
+type ListAccountInvitationsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Invitations []*AccountMember `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+}
+
+func (x *ListAccountInvitationsResponse) Reset() {
+	*x = ListAccountInvitationsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_account_invitations_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAccountInvitationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountInvitationsResponse) ProtoMessage() {}
+
+func (x *ListAccountInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_account_invitations_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_account_invitations_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListAccountInvitationsResponse) GetInvitations() []*AccountMember {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+var File_rpc_list_account_invitations_proto protoreflect.FileDescriptor
+
+var file_rpc_list_account_invitations_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x14, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1f,
+	0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x76,
+	0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x55, 0x0a, 0x1e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e,
+	0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x33, 0x0a, 0x0b, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x0b, 0x69, 0x6e, 0x76, 0x69, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x6f, 0x61, 0x6e, 0x67, 0x74, 0x6b, 0x30, 0x31, 0x30, 0x30,
+	0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2d, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_list_account_invitations_proto_rawDescOnce sync.Once
+	file_rpc_list_account_invitations_proto_rawDescData = file_rpc_list_account_invitations_proto_rawDesc
+)
+
+func file_rpc_list_account_invitations_proto_rawDescGZIP() []byte {
+	file_rpc_list_account_invitations_proto_rawDescOnce.Do(func() {
+		file_rpc_list_account_invitations_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_list_account_invitations_proto_rawDescData)
+	})
+	return file_rpc_list_account_invitations_proto_rawDescData
+}
+
+var file_rpc_list_account_invitations_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_account_invitations_proto_goTypes = []interface{}{
+	(*ListAccountInvitationsRequest)(nil),  // 0: pb.ListAccountInvitationsRequest
+	(*ListAccountInvitationsResponse)(nil), // 1: pb.ListAccountInvitationsResponse
+	(*AccountMember)(nil),                  // 2: pb.AccountMember
+}
+var file_rpc_list_account_invitations_proto_depIdxs = []int32{
+	2, // 0: pb.ListAccountInvitationsResponse.invitations:type_name -> pb.AccountMember
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_account_invitations_proto_init() }
+func file_rpc_list_account_invitations_proto_init() {
+	if File_rpc_list_account_invitations_proto != nil {
+		return
+	}
+	file_account_member_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_list_account_invitations_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAccountInvitationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_list_account_invitations_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAccountInvitationsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_list_account_invitations_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_account_invitations_proto_goTypes,
+		DependencyIndexes: file_rpc_list_account_invitations_proto_depIdxs,
+		MessageInfos:      file_rpc_list_account_invitations_proto_msgTypes,
+	}.Build()
+	File_rpc_list_account_invitations_proto = out.File
+	file_rpc_list_account_invitations_proto_rawDesc = nil
+	file_rpc_list_account_invitations_proto_goTypes = nil
+	file_rpc_list_account_invitations_proto_depIdxs = nil
+}
@@ -0,0 +1,266 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.24.4
+// source: rpc_update_fee_schedule.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UpdateFeeScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Product         string `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Currency        string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	MonthlyFee      int64  `protobuf:"varint,3,opt,name=monthly_fee,json=monthlyFee,proto3" json:"monthly_fee,omitempty"`
+	TransferFee     int64  `protobuf:"varint,4,opt,name=transfer_fee,json=transferFee,proto3" json:"transfer_fee,omitempty"`
+	MinimumBalance  int64  `protobuf:"varint,5,opt,name=minimum_balance,json=minimumBalance,proto3" json:"minimum_balance,omitempty"`
+	BelowMinimumFee int64  `protobuf:"varint,6,opt,name=below_minimum_fee,json=belowMinimumFee,proto3" json:"below_minimum_fee,omitempty"`
+}
+
+func (x *UpdateFeeScheduleRequest) Reset() {
+	*x = UpdateFeeScheduleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_update_fee_schedule_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateFeeScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFeeScheduleRequest) ProtoMessage() {}
+
+func (x *UpdateFeeScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_update_fee_schedule_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFeeScheduleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFeeScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_update_fee_schedule_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdateFeeScheduleRequest) GetProduct() string {
+	if x != nil {
+		return x.Product
+	}
+	return ""
+}
+
+func (x *UpdateFeeScheduleRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *UpdateFeeScheduleRequest) GetMonthlyFee() int64 {
+	if x != nil {
+		return x.MonthlyFee
+	}
+	return 0
+}
+
+func (x *UpdateFeeScheduleRequest) GetTransferFee() int64 {
+	if x != nil {
+		return x.TransferFee
+	}
+	return 0
+}
+
+func (x *UpdateFeeScheduleRequest) GetMinimumBalance() int64 {
+	if x != nil {
+		return x.MinimumBalance
+	}
+	return 0
+}
+
+func (x *UpdateFeeScheduleRequest) GetBelowMinimumFee() int64 {
+	if x != nil {
+		return x.BelowMinimumFee
+	}
+	return 0
+}
+
+type UpdateFeeScheduleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeeSchedule *FeeSchedule `protobuf:"bytes,1,opt,name=fee_schedule,json=feeSchedule,proto3" json:"fee_schedule,omitempty"`
+}
+
+func (x *UpdateFeeScheduleResponse) Reset() {
+	*x = UpdateFeeScheduleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_update_fee_schedule_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateFeeScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFeeScheduleResponse) ProtoMessage() {}
+
+func (x *UpdateFeeScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_update_fee_schedule_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFeeScheduleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateFeeScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_update_fee_schedule_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateFeeScheduleResponse) GetFeeSchedule() *FeeSchedule {
+	if x != nil {
+		return x.FeeSchedule
+	}
+	return nil
+}
+
+var File_rpc_update_fee_schedule_proto protoreflect.FileDescriptor
+
+var file_rpc_update_fee_schedule_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x72, 0x70, 0x63, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x65, 0x65,
+	0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x02, 0x70, 0x62, 0x1a, 0x12, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe9, 0x01, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x46, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f,
+	0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x46, 0x65, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x46, 0x65, 0x65, 0x12, 0x27,
+	0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x65, 0x6c, 0x6f, 0x77,
+	0x5f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x62, 0x65, 0x6c, 0x6f, 0x77, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d,
+	0x46, 0x65, 0x65, 0x22, 0x4f, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x65, 0x65,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x32, 0x0a, 0x0c, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x0b, 0x66, 0x65, 0x65, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x68, 0x6f, 0x61, 0x6e, 0x67, 0x74, 0x6b, 0x30, 0x31, 0x30, 0x30, 0x2f, 0x73,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2d, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_update_fee_schedule_proto_rawDescOnce sync.Once
+	file_rpc_update_fee_schedule_proto_rawDescData = file_rpc_update_fee_schedule_proto_rawDesc
+)
+
+func file_rpc_update_fee_schedule_proto_rawDescGZIP() []byte {
+	file_rpc_update_fee_schedule_proto_rawDescOnce.Do(func() {
+		file_rpc_update_fee_schedule_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_update_fee_schedule_proto_rawDescData)
+	})
+	return file_rpc_update_fee_schedule_proto_rawDescData
+}
+
+var file_rpc_update_fee_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_update_fee_schedule_proto_goTypes = []interface{}{
+	(*UpdateFeeScheduleRequest)(nil),  // 0: pb.UpdateFeeScheduleRequest
+	(*UpdateFeeScheduleResponse)(nil), // 1: pb.UpdateFeeScheduleResponse
+	(*FeeSchedule)(nil),               // 2: pb.FeeSchedule
+}
+var file_rpc_update_fee_schedule_proto_depIdxs = []int32{
+	2, // 0: pb.UpdateFeeScheduleResponse.fee_schedule:type_name -> pb.FeeSchedule
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_update_fee_schedule_proto_init() }
+func file_rpc_update_fee_schedule_proto_init() {
+	if File_rpc_update_fee_schedule_proto != nil {
+		return
+	}
+	file_fee_schedule_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_update_fee_schedule_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateFeeScheduleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_update_fee_schedule_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateFeeScheduleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_update_fee_schedule_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_update_fee_schedule_proto_goTypes,
+		DependencyIndexes: file_rpc_update_fee_schedule_proto_depIdxs,
+		MessageInfos:      file_rpc_update_fee_schedule_proto_msgTypes,
+	}.Build()
+	File_rpc_update_fee_schedule_proto = out.File
+	file_rpc_update_fee_schedule_proto_rawDesc = nil
+	file_rpc_update_fee_schedule_proto_goTypes = nil
+	file_rpc_update_fee_schedule_proto_depIdxs = nil
+}
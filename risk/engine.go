@@ -0,0 +1,82 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+)
+
+// Transfer is a transfer requested by a user, it is screened before any money moves
+type Transfer struct {
+	Username    string
+	FromAccount db.Account
+	ToAccount   db.Account
+	Amount      int64
+}
+
+// Assessment is the outcome of the screening of a transfer
+type Assessment struct {
+	// db.RiskDecisionAllow, db.RiskDecisionReview or db.RiskDecisionBlock
+	Decision string
+	// Names of the rules which triggered, in the order they were evaluated
+	Rules []string
+}
+
+// Rule looks for one fraud signal in a transfer
+type Rule interface {
+	Name() string
+	// Evaluate returns the decision asked by the rule, db.RiskDecisionAllow when it did not trigger
+	Evaluate(ctx context.Context, transfer Transfer) (string, error)
+}
+
+// Store is the part of db.Store read by the rules
+type Store interface {
+	CountTransfersBetween(ctx context.Context, arg db.CountTransfersBetweenParams) (int64, error)
+	GetDailyTransferTotal(ctx context.Context, arg db.GetDailyTransferTotalParams) (db.GetDailyTransferTotalRow, error)
+	ListUserSessions(ctx context.Context, arg db.ListUserSessionsParams) ([]db.Session, error)
+}
+
+var severities = map[string]int{
+	db.RiskDecisionAllow:  0,
+	db.RiskDecisionReview: 1,
+	db.RiskDecisionBlock:  2,
+}
+
+// Engine screens transfers with a set of rules, the most severe decision of the triggered rules wins
+type Engine struct {
+	rules []Rule
+}
+
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{
+		rules: rules,
+	}
+}
+
+// Evaluate runs every rule on the transfer
+// A transfer is allowed when no rule triggered, so an engine without rules allows everything
+func (engine *Engine) Evaluate(ctx context.Context, transfer Transfer) (Assessment, error) {
+	assessment := Assessment{
+		Decision: db.RiskDecisionAllow,
+		Rules:    []string{},
+	}
+
+	for _, rule := range engine.rules {
+		decision, err := rule.Evaluate(ctx, transfer)
+		if err != nil {
+			return Assessment{}, fmt.Errorf("failed to evaluate rule %s: %w", rule.Name(), err)
+		}
+
+		if decision == db.RiskDecisionAllow {
+			continue
+		}
+
+		assessment.Rules = append(assessment.Rules, rule.Name())
+		if severities[decision] > severities[assessment.Decision] {
+			assessment.Decision = decision
+		}
+	}
+
+	return assessment, nil
+}
@@ -0,0 +1,371 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+type staticRule struct {
+	name     string
+	decision string
+	err      error
+}
+
+func (rule staticRule) Name() string {
+	return rule.name
+}
+
+func (rule staticRule) Evaluate(ctx context.Context, transfer Transfer) (string, error) {
+	return rule.decision, rule.err
+}
+
+func randomTransfer(amount int64, balance int64) Transfer {
+	return Transfer{
+		Username: util.RandomOwner(),
+		FromAccount: db.Account{
+			ID:      util.RandomInt(1, 1000),
+			Balance: balance,
+		},
+		ToAccount: db.Account{
+			ID: util.RandomInt(1001, 2000),
+		},
+		Amount: amount,
+	}
+}
+
+func TestEngineEvaluate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rules    []Rule
+		decision string
+		triggers []string
+		err      bool
+	}{
+		{
+			name:     "NoRules",
+			decision: db.RiskDecisionAllow,
+			triggers: []string{},
+		},
+		{
+			name: "Allow",
+			rules: []Rule{
+				staticRule{name: "a", decision: db.RiskDecisionAllow},
+				staticRule{name: "b", decision: db.RiskDecisionAllow},
+			},
+			decision: db.RiskDecisionAllow,
+			triggers: []string{},
+		},
+		{
+			name: "MostSevereWins",
+			rules: []Rule{
+				staticRule{name: "a", decision: db.RiskDecisionReview},
+				staticRule{name: "b", decision: db.RiskDecisionBlock},
+				staticRule{name: "c", decision: db.RiskDecisionAllow},
+				staticRule{name: "d", decision: db.RiskDecisionReview},
+			},
+			decision: db.RiskDecisionBlock,
+			triggers: []string{"a", "b", "d"},
+		},
+		{
+			name: "RuleError",
+			rules: []Rule{
+				staticRule{name: "a", decision: db.RiskDecisionReview},
+				staticRule{name: "b", err: errors.New("failed")},
+			},
+			err: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			assessment, err := NewEngine(tc.rules...).Evaluate(context.Background(), randomTransfer(100, 1000))
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.decision, assessment.Decision)
+			require.Equal(t, tc.triggers, assessment.Rules)
+		})
+	}
+}
+
+func TestVelocityRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		count    int64
+		decision string
+	}{
+		{
+			name:     "Allow",
+			count:    3,
+			decision: db.RiskDecisionAllow,
+		},
+		{
+			name:     "Review",
+			count:    4,
+			decision: db.RiskDecisionReview,
+		},
+		{
+			name:     "Block",
+			count:    9,
+			decision: db.RiskDecisionBlock,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			transfer := randomTransfer(100, 1000)
+
+			store.EXPECT().
+				GetDailyTransferTotal(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, arg db.GetDailyTransferTotalParams) (db.GetDailyTransferTotalRow, error) {
+					require.Equal(t, transfer.FromAccount.ID, arg.FromAccountID)
+					require.WithinDuration(t, time.Now().Add(-10*time.Minute), arg.Since, time.Second)
+					return db.GetDailyTransferTotalRow{Count: tc.count}, nil
+				})
+
+			rule := &VelocityRule{
+				Store:       store,
+				Window:      10 * time.Minute,
+				ReviewCount: 5,
+				BlockCount:  10,
+			}
+
+			decision, err := rule.Evaluate(context.Background(), transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.decision, decision)
+		})
+	}
+}
+
+func TestNewPayeeRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		count    int64
+		amount   int64
+		decision string
+	}{
+		{
+			name:     "KnownPayee",
+			count:    1,
+			amount:   900,
+			decision: db.RiskDecisionAllow,
+		},
+		{
+			name:     "SmallAmount",
+			count:    0,
+			amount:   499,
+			decision: db.RiskDecisionAllow,
+		},
+		{
+			name:     "Review",
+			count:    0,
+			amount:   500,
+			decision: db.RiskDecisionReview,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			transfer := randomTransfer(tc.amount, 1000)
+
+			arg := db.CountTransfersBetweenParams{
+				FromAccountID: transfer.FromAccount.ID,
+				ToAccountID:   transfer.ToAccount.ID,
+			}
+			store.EXPECT().
+				CountTransfersBetween(gomock.Any(), gomock.Eq(arg)).
+				Times(1).
+				Return(tc.count, nil)
+
+			rule := &NewPayeeRule{
+				Store:          store,
+				BalancePercent: 50,
+			}
+
+			decision, err := rule.Evaluate(context.Background(), transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.decision, decision)
+		})
+	}
+}
+
+func TestUnusualAmountRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		history  db.GetDailyTransferTotalRow
+		amount   int64
+		decision string
+	}{
+		{
+			name:     "ShortHistory",
+			history:  db.GetDailyTransferTotalRow{Amount: 40, Count: 4},
+			amount:   10000,
+			decision: db.RiskDecisionAllow,
+		},
+		{
+			name:     "UsualAmount",
+			history:  db.GetDailyTransferTotalRow{Amount: 500, Count: 5},
+			amount:   1000,
+			decision: db.RiskDecisionAllow,
+		},
+		{
+			name:     "Review",
+			history:  db.GetDailyTransferTotalRow{Amount: 500, Count: 5},
+			amount:   1001,
+			decision: db.RiskDecisionReview,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetDailyTransferTotal(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(tc.history, nil)
+
+			rule := &UnusualAmountRule{
+				Store:      store,
+				Multiplier: 10,
+			}
+
+			decision, err := rule.Evaluate(context.Background(), randomTransfer(tc.amount, 100000))
+			require.NoError(t, err)
+			require.Equal(t, tc.decision, decision)
+		})
+	}
+}
+
+func TestNewSessionRule(t *testing.T) {
+	newSession := func(clientIP string, userAgent string, age time.Duration) db.Session {
+		return db.Session{
+			ClientIp:  clientIP,
+			UserAgent: userAgent,
+			CreatedAt: time.Now().Add(-age),
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		sessions []db.Session
+		decision string
+	}{
+		{
+			name: "FirstSession",
+			sessions: []db.Session{
+				newSession("10.0.0.1:5000", "app", time.Minute),
+			},
+			decision: db.RiskDecisionAllow,
+		},
+		{
+			name: "KnownDevice",
+			sessions: []db.Session{
+				newSession("10.0.0.1:5000", "app", time.Minute),
+				newSession("10.0.0.1:6000", "app", 48*time.Hour),
+			},
+			decision: db.RiskDecisionAllow,
+		},
+		{
+			name: "OldSession",
+			sessions: []db.Session{
+				newSession("10.0.0.2:5000", "app", 25*time.Hour),
+				newSession("10.0.0.1:6000", "app", 48*time.Hour),
+			},
+			decision: db.RiskDecisionAllow,
+		},
+		{
+			name: "NewClientIP",
+			sessions: []db.Session{
+				newSession("10.0.0.2:5000", "app", time.Minute),
+				newSession("10.0.0.1:6000", "app", 48*time.Hour),
+			},
+			decision: db.RiskDecisionReview,
+		},
+		{
+			name: "NewUserAgent",
+			sessions: []db.Session{
+				newSession("10.0.0.1:5000", "browser", time.Minute),
+				newSession("10.0.0.1:6000", "app", 48*time.Hour),
+			},
+			decision: db.RiskDecisionReview,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			transfer := randomTransfer(100, 1000)
+
+			store.EXPECT().
+				ListUserSessions(gomock.Any(), gomock.Eq(db.ListUserSessionsParams{
+					Username: transfer.Username,
+					Limit:    maxSessionHistory,
+				})).
+				Times(1).
+				Return(tc.sessions, nil)
+
+			rule := &NewSessionRule{
+				Store:  store,
+				Window: 24 * time.Hour,
+			}
+
+			decision, err := rule.Evaluate(context.Background(), transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.decision, decision)
+		})
+	}
+}
+
+func TestDefaultRules(t *testing.T) {
+	require.Empty(t, DefaultRules(nil, util.Config{}))
+
+	rules := DefaultRules(nil, util.Config{
+		RiskVelocityWindow:          time.Minute,
+		RiskVelocityReviewCount:     5,
+		RiskNewPayeeBalancePercent:  50,
+		RiskUnusualAmountMultiplier: 10,
+		RiskNewSessionWindow:        time.Hour,
+	})
+
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.Name())
+	}
+	require.Equal(t, []string{RuleVelocity, RuleNewPayee, RuleUnusualAmount, RuleNewSession}, names)
+}
@@ -0,0 +1,215 @@
+package risk
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"time"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/util"
+)
+
+// Names of the rules, recorded with each decision
+const (
+	RuleVelocity      = "velocity"
+	RuleNewPayee      = "new_payee"
+	RuleUnusualAmount = "unusual_amount"
+	RuleNewSession    = "new_session"
+)
+
+const (
+	// amountHistory is how far back the usual amount of an account is computed
+	amountHistory = 90 * 24 * time.Hour
+	// minAmountHistory is the number of past transfers needed before an amount can look unusual
+	minAmountHistory = 5
+	// maxSessionHistory is the number of past sessions searched for the device of a new one
+	maxSessionHistory = 50
+)
+
+// DefaultRules returns the rules enabled by the config, a rule is disabled while its thresholds are zero
+func DefaultRules(store Store, config util.Config) []Rule {
+	var rules []Rule
+
+	if config.RiskVelocityWindow > 0 && (config.RiskVelocityReviewCount > 0 || config.RiskVelocityBlockCount > 0) {
+		rules = append(rules, &VelocityRule{
+			Store:       store,
+			Window:      config.RiskVelocityWindow,
+			ReviewCount: config.RiskVelocityReviewCount,
+			BlockCount:  config.RiskVelocityBlockCount,
+		})
+	}
+
+	if config.RiskNewPayeeBalancePercent > 0 {
+		rules = append(rules, &NewPayeeRule{
+			Store:          store,
+			BalancePercent: config.RiskNewPayeeBalancePercent,
+		})
+	}
+
+	if config.RiskUnusualAmountMultiplier > 0 {
+		rules = append(rules, &UnusualAmountRule{
+			Store:      store,
+			Multiplier: config.RiskUnusualAmountMultiplier,
+		})
+	}
+
+	if config.RiskNewSessionWindow > 0 {
+		rules = append(rules, &NewSessionRule{
+			Store:  store,
+			Window: config.RiskNewSessionWindow,
+		})
+	}
+
+	return rules
+}
+
+// VelocityRule reviews or blocks the transfer which makes the account reach a number of transfers within the window
+// A zero count disables the matching decision
+type VelocityRule struct {
+	Store       Store
+	Window      time.Duration
+	ReviewCount int64
+	BlockCount  int64
+}
+
+func (rule *VelocityRule) Name() string {
+	return RuleVelocity
+}
+
+func (rule *VelocityRule) Evaluate(ctx context.Context, transfer Transfer) (string, error) {
+	total, err := rule.Store.GetDailyTransferTotal(ctx, db.GetDailyTransferTotalParams{
+		FromAccountID: transfer.FromAccount.ID,
+		Since:         time.Now().Add(-rule.Window),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// The screened transfer counts too
+	count := total.Count + 1
+	if rule.BlockCount > 0 && count >= rule.BlockCount {
+		return db.RiskDecisionBlock, nil
+	}
+
+	if rule.ReviewCount > 0 && count >= rule.ReviewCount {
+		return db.RiskDecisionReview, nil
+	}
+
+	return db.RiskDecisionAllow, nil
+}
+
+// NewPayeeRule reviews the first transfer to an account when it sends at least BalancePercent of the balance
+type NewPayeeRule struct {
+	Store          Store
+	BalancePercent int64
+}
+
+func (rule *NewPayeeRule) Name() string {
+	return RuleNewPayee
+}
+
+func (rule *NewPayeeRule) Evaluate(ctx context.Context, transfer Transfer) (string, error) {
+	count, err := rule.Store.CountTransfersBetween(ctx, db.CountTransfersBetweenParams{
+		FromAccountID: transfer.FromAccount.ID,
+		ToAccountID:   transfer.ToAccount.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if count == 0 && compareProducts(transfer.Amount, 100, transfer.FromAccount.Balance, rule.BalancePercent) >= 0 {
+		return db.RiskDecisionReview, nil
+	}
+
+	return db.RiskDecisionAllow, nil
+}
+
+// UnusualAmountRule reviews a transfer larger than Multiplier times the average of the recent transfers of the account
+// Accounts with too short a history are not checked
+type UnusualAmountRule struct {
+	Store      Store
+	Multiplier int64
+}
+
+func (rule *UnusualAmountRule) Name() string {
+	return RuleUnusualAmount
+}
+
+func (rule *UnusualAmountRule) Evaluate(ctx context.Context, transfer Transfer) (string, error) {
+	// The daily total query sums the customer transfers since any time, here over the whole history window
+	history, err := rule.Store.GetDailyTransferTotal(ctx, db.GetDailyTransferTotalParams{
+		FromAccountID: transfer.FromAccount.ID,
+		Since:         time.Now().Add(-amountHistory),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if history.Count < minAmountHistory {
+		return db.RiskDecisionAllow, nil
+	}
+
+	// amount > multiplier * total / count, without rounding the average
+	if compareProducts(transfer.Amount, history.Count, history.Amount, rule.Multiplier) > 0 {
+		return db.RiskDecisionReview, nil
+	}
+
+	return db.RiskDecisionAllow, nil
+}
+
+// NewSessionRule reviews the transfers of a user who logged in within the window from a device never seen before
+// The device is the pair of client IP and user agent of the session
+type NewSessionRule struct {
+	Store  Store
+	Window time.Duration
+}
+
+func (rule *NewSessionRule) Name() string {
+	return RuleNewSession
+}
+
+func (rule *NewSessionRule) Evaluate(ctx context.Context, transfer Transfer) (string, error) {
+	sessions, err := rule.Store.ListUserSessions(ctx, db.ListUserSessionsParams{
+		Username: transfer.Username,
+		Limit:    maxSessionHistory,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// The first session of a user has no device to be compared with
+	if len(sessions) < 2 {
+		return db.RiskDecisionAllow, nil
+	}
+
+	latest := sessions[0]
+	if time.Since(latest.CreatedAt) > rule.Window {
+		return db.RiskDecisionAllow, nil
+	}
+
+	for _, session := range sessions[1:] {
+		if clientHost(session.ClientIp) == clientHost(latest.ClientIp) && session.UserAgent == latest.UserAgent {
+			return db.RiskDecisionAllow, nil
+		}
+	}
+
+	return db.RiskDecisionReview, nil
+}
+
+// clientHost drops the port of the client address, which changes with every connection
+func clientHost(clientIP string) string {
+	host, _, err := net.SplitHostPort(clientIP)
+	if err != nil {
+		return clientIP
+	}
+
+	return host
+}
+
+// compareProducts compares a*x with b*y without overflowing
+func compareProducts(a, x, b, y int64) int {
+	left := new(big.Int).Mul(big.NewInt(a), big.NewInt(x))
+	right := new(big.Int).Mul(big.NewInt(b), big.NewInt(y))
+	return left.Cmp(right)
+}
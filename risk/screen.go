@@ -0,0 +1,102 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+)
+
+// ErrTransferBlocked is returned for a transfer rejected by the screening
+// The triggered rules are kept for the bankers, the client only learns that the transfer was blocked
+var ErrTransferBlocked = errors.New("transfer was blocked by fraud screening")
+
+// ScreenStore is the part of db.Store used to screen a transfer and act on the decision
+type ScreenStore interface {
+	GetIdempotentTransfer(ctx context.Context, arg db.TransferTxParams) (db.IdempotentTransferResult, error)
+	CreateRiskDecision(ctx context.Context, arg db.CreateRiskDecisionParams) (db.RiskDecision, error)
+	HoldTransferForReviewTx(ctx context.Context, arg db.HoldTransferForReviewTxParams) (db.HoldTransferForReviewTxResult, error)
+}
+
+// ScreenParams contains the input parameters of Screen
+type ScreenParams struct {
+	Transfer
+	// The transfer to send once allowed
+	Request db.TransferTxParams
+	// How long the funds of a transfer sent for review are held
+	HoldDuration time.Duration
+}
+
+// Screening is the outcome of Screen, exactly one field is set
+type Screening struct {
+	// The allowed transfer to send, its decision is recorded along with it
+	Allowed *db.TransferTxParams
+	// The result committed by an earlier attempt with the same idempotency key
+	Transfer *db.TransferTxResult
+	// The hold on the funds of a transfer waiting for a review
+	Review *db.HoldTransferForReviewTxResult
+}
+
+// Screen decides what happens to a transfer requested by a user before any money moves.
+// A retry replays the result committed with its idempotency key without being screened again, the rules would count its first attempt.
+// A blocked transfer is recorded and fails with ErrTransferBlocked, one sent for review has its funds held until a banker resolves it.
+// Only interactive requests are screened: scheduled transfers and batch rows run in the background without the session the rules look at,
+// they are checked by AuthorizeAccount and the transfer limits when they run, and a captured hold was screened when it was placed.
+func (engine *Engine) Screen(ctx context.Context, store ScreenStore, arg ScreenParams) (Screening, error) {
+	if arg.Request.Idempotency != nil {
+		replay, err := store.GetIdempotentTransfer(ctx, arg.Request)
+		if err == nil {
+			return Screening{Transfer: replay.Transfer, Review: replay.Review}, nil
+		}
+		if !errors.Is(err, db.ErrRecordNotFound) {
+			return Screening{}, err
+		}
+	}
+
+	assessment, err := engine.Evaluate(ctx, arg.Transfer)
+	if err != nil {
+		return Screening{}, fmt.Errorf("failed to screen transfer: %w", err)
+	}
+
+	decision := db.CreateRiskDecisionParams{
+		Username:      arg.Username,
+		FromAccountID: arg.FromAccount.ID,
+		ToAccountID:   arg.ToAccount.ID,
+		Amount:        arg.Amount,
+		Decision:      assessment.Decision,
+		Rules:         assessment.Rules,
+	}
+
+	// A hold is captured in the currency of its account, so a cross-currency transfer can not wait for a review
+	if decision.Decision == db.RiskDecisionReview && arg.ToAccount.Currency != arg.FromAccount.Currency {
+		decision.Decision = db.RiskDecisionBlock
+	}
+
+	switch decision.Decision {
+	case db.RiskDecisionBlock:
+		_, err = store.CreateRiskDecision(ctx, decision)
+		if err != nil {
+			return Screening{}, fmt.Errorf("failed to record risk decision: %w", err)
+		}
+
+		return Screening{}, ErrTransferBlocked
+	case db.RiskDecisionReview:
+		review, err := store.HoldTransferForReviewTx(ctx, db.HoldTransferForReviewTxParams{
+			TransferTxParams: arg.Request,
+			Username:         decision.Username,
+			Rules:            decision.Rules,
+			ExpiresAt:        time.Now().Add(arg.HoldDuration),
+		})
+		if err != nil {
+			return Screening{}, err
+		}
+
+		return Screening{Review: &review}, nil
+	}
+
+	allowed := arg.Request
+	allowed.RiskDecision = &decision
+	return Screening{Allowed: &allowed}, nil
+}
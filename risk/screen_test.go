@@ -0,0 +1,192 @@
+package risk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hoangtk0100/simple-bank/db/mock"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScreen(t *testing.T) {
+	transfer := randomTransfer(10, 100)
+	transfer.FromAccount.Currency = util.USD
+	transfer.ToAccount.Currency = util.USD
+
+	request := db.TransferTxParams{
+		FromAccountID: transfer.FromAccount.ID,
+		ToAccountID:   transfer.ToAccount.ID,
+		Amount:        transfer.Amount,
+	}
+
+	idempotentRequest := request
+	idempotentRequest.Idempotency = &db.IdempotencyParams{
+		Username: transfer.Username,
+		Key:      "transfer-key",
+	}
+
+	testCases := []struct {
+		name          string
+		decision      string
+		toCurrency    string
+		request       db.TransferTxParams
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, screening Screening, err error)
+	}{
+		{
+			name:     "Allowed",
+			decision: db.RiskDecisionAllow,
+			request:  request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetIdempotentTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateRiskDecision(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().HoldTransferForReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, screening Screening, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, screening.Allowed)
+				require.Equal(t, request.Amount, screening.Allowed.Amount)
+				require.Equal(t, db.RiskDecisionAllow, screening.Allowed.RiskDecision.Decision)
+				require.Equal(t, transfer.Username, screening.Allowed.RiskDecision.Username)
+			},
+		},
+		{
+			name:     "Blocked",
+			decision: db.RiskDecisionBlock,
+			request:  request,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateRiskDecisionParams{
+					Username:      transfer.Username,
+					FromAccountID: transfer.FromAccount.ID,
+					ToAccountID:   transfer.ToAccount.ID,
+					Amount:        transfer.Amount,
+					Decision:      db.RiskDecisionBlock,
+					Rules:         []string{"static"},
+				}
+				store.EXPECT().CreateRiskDecision(gomock.Any(), gomock.Eq(arg)).Times(1)
+				store.EXPECT().HoldTransferForReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, screening Screening, err error) {
+				require.ErrorIs(t, err, ErrTransferBlocked)
+			},
+		},
+		{
+			name:     "HeldForReview",
+			decision: db.RiskDecisionReview,
+			request:  request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateRiskDecision(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					HoldTransferForReviewTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.HoldTransferForReviewTxParams) (db.HoldTransferForReviewTxResult, error) {
+						require.Equal(t, request, arg.TransferTxParams)
+						require.Equal(t, transfer.Username, arg.Username)
+						require.Equal(t, []string{"static"}, arg.Rules)
+						require.WithinDuration(t, time.Now().Add(time.Hour), arg.ExpiresAt, time.Second)
+						return db.HoldTransferForReviewTxResult{Hold: db.Hold{ID: 1}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, screening Screening, err error) {
+				require.NoError(t, err)
+				require.Nil(t, screening.Allowed)
+				require.Equal(t, int64(1), screening.Review.Hold.ID)
+			},
+		},
+		{
+			name:       "CrossCurrencyReview",
+			decision:   db.RiskDecisionReview,
+			toCurrency: util.EUR,
+			request:    request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRiskDecision(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreateRiskDecisionParams) (db.RiskDecision, error) {
+						require.Equal(t, db.RiskDecisionBlock, arg.Decision)
+						return db.RiskDecision{}, nil
+					})
+				store.EXPECT().HoldTransferForReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, screening Screening, err error) {
+				require.ErrorIs(t, err, ErrTransferBlocked)
+			},
+		},
+		{
+			name:     "Replayed",
+			decision: db.RiskDecisionBlock,
+			request:  idempotentRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIdempotentTransfer(gomock.Any(), gomock.Eq(idempotentRequest)).
+					Times(1).
+					Return(db.IdempotentTransferResult{Transfer: &db.TransferTxResult{Transfer: db.Transfer{ID: 1}}}, nil)
+				store.EXPECT().CreateRiskDecision(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().HoldTransferForReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, screening Screening, err error) {
+				require.NoError(t, err)
+				require.Nil(t, screening.Allowed)
+				require.Equal(t, int64(1), screening.Transfer.Transfer.ID)
+			},
+		},
+		{
+			name:     "IdempotencyKeyConflict",
+			decision: db.RiskDecisionAllow,
+			request:  idempotentRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIdempotentTransfer(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotentTransferResult{}, db.ErrIdempotencyKeyConflict)
+			},
+			checkResponse: func(t *testing.T, screening Screening, err error) {
+				require.ErrorIs(t, err, db.ErrIdempotencyKeyConflict)
+			},
+		},
+		{
+			name:     "NotReplayed",
+			decision: db.RiskDecisionAllow,
+			request:  idempotentRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIdempotentTransfer(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotentTransferResult{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, screening Screening, err error) {
+				require.NoError(t, err)
+				require.Equal(t, idempotentRequest.Idempotency, screening.Allowed.Idempotency)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			arg := ScreenParams{
+				Transfer:     transfer,
+				Request:      tc.request,
+				HoldDuration: time.Hour,
+			}
+			if tc.toCurrency != "" {
+				arg.ToAccount.Currency = tc.toCurrency
+			}
+
+			engine := NewEngine(staticRule{name: "static", decision: tc.decision})
+			screening, err := engine.Screen(context.Background(), store, arg)
+			tc.checkResponse(t, screening, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteCSV exports the statement as a summary block followed by one row per entry
+func (statement Statement) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	records := [][]string{
+		{"Account", strconv.FormatInt(statement.Account.ID, 10)},
+		{"Owner", statement.Account.Owner},
+		{"Currency", statement.Currency.Code},
+		{"From", statement.FromDate.Format(DateLayout)},
+		{"To", statement.ToDate.Format(DateLayout)},
+		{"Opening balance", statement.formatAmount(statement.OpeningBalance)},
+		{"Closing balance", statement.formatAmount(statement.ClosingBalance)},
+		{},
+		{"Date", "Entry ID", "Description", "Transfer ID", "Counterpart account ID", "Counterpart owner", "Amount", "Balance"},
+	}
+
+	for _, line := range statement.Lines {
+		records = append(records, []string{
+			line.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatInt(line.EntryID, 10),
+			line.Description,
+			formatID(line.TransferID),
+			formatID(line.CounterpartAccountID),
+			line.CounterpartOwner,
+			statement.formatAmount(line.Amount),
+			statement.formatAmount(line.Balance),
+		})
+	}
+
+	return writer.WriteAll(records)
+}
+
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
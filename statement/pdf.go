@@ -0,0 +1,156 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Layout of the PDF pages: A4 portrait with a monospaced font so that columns line up
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 40
+	pdfFontSize     = 8
+	pdfLeading      = 11
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+const pdfRowFormat = "%-20s %-8s %-30s %-12s %14s %14s"
+
+// WritePDF exports the statement as a plain text PDF document
+func (statement Statement) WritePDF(w io.Writer) error {
+	lines := statement.textLines()
+
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	_, err := w.Write(renderPDF(pages))
+	return err
+}
+
+func (statement Statement) textLines() []string {
+	lines := []string{
+		"ACCOUNT STATEMENT",
+		"",
+		fmt.Sprintf("Account:  %d", statement.Account.ID),
+		fmt.Sprintf("Owner:    %s", statement.Account.Owner),
+		fmt.Sprintf("Currency: %s", statement.Currency.Code),
+		fmt.Sprintf("Period:   %s to %s", statement.FromDate.Format(DateLayout), statement.ToDate.Format(DateLayout)),
+		"",
+		fmt.Sprintf("Opening balance: %s", statement.formatAmount(statement.OpeningBalance)),
+		"",
+		fmt.Sprintf(pdfRowFormat, "Date", "Entry", "Description", "Counterpart", "Amount", "Balance"),
+		strings.Repeat("-", 103),
+	}
+
+	for _, line := range statement.Lines {
+		lines = append(lines, fmt.Sprintf(
+			pdfRowFormat,
+			line.CreatedAt.UTC().Format("2006-01-02 15:04:05"),
+			fmt.Sprint(line.EntryID),
+			truncate(line.Description, 30),
+			truncate(line.CounterpartOwner, 12),
+			statement.formatAmount(line.Amount),
+			statement.formatAmount(line.Balance),
+		))
+	}
+
+	return append(lines,
+		strings.Repeat("-", 103),
+		fmt.Sprintf("Closing balance: %s", statement.formatAmount(statement.ClosingBalance)),
+	)
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+
+	return value[:length]
+}
+
+// renderPDF writes a minimal PDF 1.4 file, each page shows its lines with the built-in Courier font
+func renderPDF(pages [][]string) []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	startObject := func() int {
+		offsets = append(offsets, buf.Len())
+		id := len(offsets)
+		fmt.Fprintf(&buf, "%d 0 obj\n", id)
+		return id
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// Objects 1-3 are the catalog, the page tree and the font, then a page and its content for each page
+	startObject()
+	buf.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	kids := make([]string, 0, len(pages))
+	for index := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*index))
+	}
+
+	startObject()
+	fmt.Fprintf(&buf, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(pages))
+
+	startObject()
+	buf.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>\nendobj\n")
+
+	for _, lines := range pages {
+		pageID := startObject()
+		fmt.Fprintf(
+			&buf,
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+			pdfPageWidth,
+			pdfPageHeight,
+			pageID+1,
+		)
+
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range lines {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", escapePDFText(line))
+		}
+		content.WriteString("ET\n")
+
+		startObject()
+		fmt.Fprintf(&buf, "<< /Length %d >>\nstream\n", content.Len())
+		buf.Write(content.Bytes())
+		buf.WriteString("endstream\nendobj\n")
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	return buf.Bytes()
+}
+
+// escapePDFText escapes the string delimiters and replaces what the standard font can not show
+func escapePDFText(value string) string {
+	var sb strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			sb.WriteByte('?')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
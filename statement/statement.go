@@ -0,0 +1,151 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/currency"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+)
+
+// Format is the file format of an exported statement
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	FormatPDF Format = "pdf"
+)
+
+// DateLayout is the layout of the statement period dates
+const DateLayout = "2006-01-02"
+
+var ErrUnsupportedFormat = errors.New("unsupported statement format")
+
+// Statement lists the entries of an account for a period of whole days
+type Statement struct {
+	Account        db.Account
+	Currency       currency.Currency
+	FromDate       time.Time
+	ToDate         time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+	Lines          []Line
+}
+
+// Line is a single entry of the statement with the balance right after it
+type Line struct {
+	EntryID              int64
+	CreatedAt            time.Time
+	Description          string
+	TransferID           int64
+	CounterpartAccountID int64
+	CounterpartOwner     string
+	Amount               int64
+	Balance              int64
+}
+
+// New builds the statement of the period from fromDate to toDate, both days included
+func New(result db.AccountStatementTxResult, cur currency.Currency, fromDate, toDate time.Time) Statement {
+	statement := Statement{
+		Account:        result.Account,
+		Currency:       cur,
+		FromDate:       fromDate,
+		ToDate:         toDate,
+		OpeningBalance: result.OpeningBalance,
+		ClosingBalance: result.ClosingBalance,
+		Lines:          make([]Line, 0, len(result.Entries)),
+	}
+
+	balance := result.OpeningBalance
+	for _, entry := range result.Entries {
+		balance += entry.Amount
+
+		statement.Lines = append(statement.Lines, Line{
+			EntryID:              entry.ID,
+			CreatedAt:            entry.CreatedAt,
+			Description:          describe(entry),
+			TransferID:           entry.TransferID.Int64,
+			CounterpartAccountID: entry.CounterpartAccountID.Int64,
+			CounterpartOwner:     entry.CounterpartOwner.String,
+			Amount:               entry.Amount,
+			Balance:              balance,
+		})
+	}
+
+	return statement
+}
+
+func describe(entry db.ListStatementEntriesRow) string {
+	if !entry.TransferID.Valid {
+		return "Balance adjustment"
+	}
+
+	// A reversed deposit or withdrawal is described as a reversal too
+	if entry.ReversalOfTransferID.Valid {
+		return fmt.Sprintf("Reversal of transfer %d", entry.ReversalOfTransferID.Int64)
+	}
+
+	// Deposits, withdrawals and adjustments are transfers with the internal accounts of the bank
+	switch entry.CounterpartOwner.String {
+	case db.SystemCashOwner:
+		if entry.Amount < 0 {
+			return "Withdrawal"
+		}
+
+		return "Deposit"
+	case db.SystemSuspenseOwner:
+		return "Balance adjustment"
+	}
+
+	// The account of the recipient of a payment is not listed for the payer
+	if !entry.CounterpartAccountID.Valid {
+		return fmt.Sprintf("Payment to %s", entry.CounterpartOwner.String)
+	}
+
+	if entry.Amount < 0 {
+		return fmt.Sprintf("Transfer to account %d", entry.CounterpartAccountID.Int64)
+	}
+
+	return fmt.Sprintf("Transfer from account %d", entry.CounterpartAccountID.Int64)
+}
+
+// ContentType returns the MIME type of the format
+func ContentType(format Format) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatPDF:
+		return "application/pdf"
+	}
+
+	return "application/octet-stream"
+}
+
+// FileName returns a descriptive name for the exported file
+func (statement Statement) FileName(format Format) string {
+	return fmt.Sprintf(
+		"statement-%d-%s-%s.%s",
+		statement.Account.ID,
+		statement.FromDate.Format(DateLayout),
+		statement.ToDate.Format(DateLayout),
+		format,
+	)
+}
+
+// Write exports the statement in the given format
+func (statement Statement) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatCSV:
+		return statement.WriteCSV(w)
+	case FormatPDF:
+		return statement.WritePDF(w)
+	}
+
+	return ErrUnsupportedFormat
+}
+
+func (statement Statement) formatAmount(amount int64) string {
+	return currency.NewMoney(amount, statement.Currency).Decimal()
+}
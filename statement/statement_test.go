@@ -0,0 +1,172 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/currency"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+var usd = currency.Currency{Code: "USD", NumericCode: 840, Exponent: 2, Symbol: "$", Enabled: true}
+
+func randomStatement(n int) Statement {
+	result := db.AccountStatementTxResult{
+		Account: db.Account{
+			ID:       1,
+			Owner:    "alice",
+			Currency: usd.Code,
+		},
+		OpeningBalance: 10000,
+	}
+
+	balance := result.OpeningBalance
+	for index := 0; index < n; index++ {
+		amount := int64(-150)
+		if index%2 == 1 {
+			amount = 250
+		}
+		balance += amount
+
+		result.Entries = append(result.Entries, db.ListStatementEntriesRow{
+			ID:                   int64(index + 1),
+			Amount:               amount,
+			CreatedAt:            time.Date(2026, 1, 2, 10, 0, index, 0, time.UTC),
+			TransferID:           pgtype.Int8{Int64: int64(index + 1), Valid: true},
+			CounterpartAccountID: pgtype.Int8{Int64: 2, Valid: true},
+			CounterpartOwner:     pgtype.Text{String: "bob", Valid: true},
+			CounterpartCurrency:  pgtype.Text{String: usd.Code, Valid: true},
+		})
+	}
+	result.ClosingBalance = balance
+
+	fromDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	return New(result, usd, fromDate, toDate)
+}
+
+func TestNew(t *testing.T) {
+	statement := randomStatement(2)
+
+	require.Len(t, statement.Lines, 2)
+	require.Equal(t, "Transfer to account 2", statement.Lines[0].Description)
+	require.Equal(t, int64(9850), statement.Lines[0].Balance)
+	require.Equal(t, "Transfer from account 2", statement.Lines[1].Description)
+	require.Equal(t, int64(10100), statement.Lines[1].Balance)
+	require.Equal(t, statement.ClosingBalance, statement.Lines[1].Balance)
+	require.Equal(t, "statement-1-2026-01-01-2026-01-31.pdf", statement.FileName(FormatPDF))
+}
+
+func TestNewSystemCounterpart(t *testing.T) {
+	entries := []db.ListStatementEntriesRow{
+		{Amount: 500, CounterpartOwner: pgtype.Text{String: db.SystemCashOwner, Valid: true}},
+		{Amount: -200, CounterpartOwner: pgtype.Text{String: db.SystemCashOwner, Valid: true}},
+		{Amount: -100, CounterpartOwner: pgtype.Text{String: db.SystemSuspenseOwner, Valid: true}},
+	}
+	descriptions := []string{"Deposit", "Withdrawal", "Balance adjustment"}
+
+	for index, entry := range entries {
+		entry.TransferID = pgtype.Int8{Int64: int64(index + 1), Valid: true}
+		require.Equal(t, descriptions[index], describe(entry))
+	}
+}
+
+func TestNewReversal(t *testing.T) {
+	entry := db.ListStatementEntriesRow{
+		Amount:               500,
+		TransferID:           pgtype.Int8{Int64: 8, Valid: true},
+		ReversalOfTransferID: pgtype.Int8{Int64: 5, Valid: true},
+		CounterpartOwner:     pgtype.Text{String: db.SystemCashOwner, Valid: true},
+	}
+
+	require.Equal(t, "Reversal of transfer 5", describe(entry))
+}
+
+func TestWriteCSV(t *testing.T) {
+	statement := randomStatement(2)
+
+	var buf bytes.Buffer
+	require.NoError(t, statement.Write(&buf, FormatCSV))
+
+	reader := csv.NewReader(&buf)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"Opening balance", "100.00"}, records[5])
+	require.Equal(t, []string{"Closing balance", "101.00"}, records[6])
+	// The blank separator line is skipped by the reader
+	require.Len(t, records, 8+len(statement.Lines))
+	require.Equal(t, "Date", records[7][0])
+	require.Equal(t, []string{"2026-01-02T10:00:00Z", "1", "Transfer to account 2", "1", "2", "bob", "-1.50", "98.50"}, records[8])
+}
+
+func TestWriteCSVPayment(t *testing.T) {
+	// The account of the recipient is not listed for the entry of the payer
+	result := db.AccountStatementTxResult{
+		Account: db.Account{ID: 1, Owner: "alice", Currency: usd.Code},
+		Entries: []db.ListStatementEntriesRow{
+			{
+				ID:                  1,
+				Amount:              -150,
+				CreatedAt:           time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC),
+				TransferID:          pgtype.Int8{Int64: 7, Valid: true},
+				CounterpartOwner:    pgtype.Text{String: "bob@example.com", Valid: true},
+				CounterpartCurrency: pgtype.Text{String: usd.Code, Valid: true},
+			},
+		},
+		OpeningBalance: 10000,
+		ClosingBalance: 9850,
+	}
+	fromDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	statement := New(result, usd, fromDate, fromDate.AddDate(0, 1, 0))
+
+	var buf bytes.Buffer
+	require.NoError(t, statement.Write(&buf, FormatCSV))
+
+	reader := csv.NewReader(&buf)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"2026-01-02T10:00:00Z", "1", "Payment to bob@example.com", "7", "", "bob@example.com", "-1.50", "98.50"}, records[8])
+}
+
+func TestWritePDF(t *testing.T) {
+	// Enough entries to need several pages
+	statement := randomStatement(2 * pdfLinesPerPage)
+
+	var buf bytes.Buffer
+	require.NoError(t, statement.Write(&buf, FormatPDF))
+
+	data := buf.String()
+	require.True(t, strings.HasPrefix(data, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(data, "%%EOF\n"))
+	require.Contains(t, data, "/Count 3")
+	require.Contains(t, data, "(Opening balance: 100.00) Tj")
+	require.Contains(t, data, fmt.Sprintf("(Closing balance: %s) Tj", statement.formatAmount(statement.ClosingBalance)))
+
+	// The trailer must point at the cross-reference table
+	index := strings.LastIndex(data, "startxref\n")
+	require.NotEqual(t, -1, index)
+	offset, err := strconv.Atoi(strings.Fields(data[index+len("startxref\n"):])[0])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(data[offset:], "xref\n"))
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	statement := randomStatement(1)
+
+	var buf bytes.Buffer
+	require.ErrorIs(t, statement.Write(&buf, Format("xlsx")), ErrUnsupportedFormat)
+}
+
+func TestEscapePDFText(t *testing.T) {
+	require.Equal(t, `a\(b\)c\\d?`, escapePDFText("a(b)c\\d€"))
+}
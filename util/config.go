@@ -9,19 +9,37 @@ import (
 // Config stores all the configurations of application
 // The values are read by viper from a configuration file or environment variables
 type Config struct {
-	Environment          string        `mapstructure:"ENVIRONMENT"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	MigrationURL         string        `mapstructure:"MIGRATION_URL"`
-	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
-	RedisPassword        string        `mapstructure:"REDIS_PASSWORD"`
-	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
-	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
-	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	Environment                string        `mapstructure:"ENVIRONMENT"`
+	DBSource                   string        `mapstructure:"DB_SOURCE"`
+	MigrationURL               string        `mapstructure:"MIGRATION_URL"`
+	RedisAddress               string        `mapstructure:"REDIS_ADDRESS"`
+	RedisPassword              string        `mapstructure:"REDIS_PASSWORD"`
+	HTTPServerAddress          string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GRPCServerAddress          string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	TokenSymmetricKey          string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration        time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration       time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	EmailSenderName            string        `mapstructure:"EMAIL_SENDER_NAME"`
+	EmailSenderAddress         string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	EmailSenderPassword        string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	IdempotencyKeyTTL          time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+	CurrencyRefreshInterval    time.Duration `mapstructure:"CURRENCY_REFRESH_INTERVAL"`
+	ReconcileAccountsSchedule  string        `mapstructure:"RECONCILE_ACCOUNTS_SCHEDULE"`
+	ExpireHoldsSchedule        string        `mapstructure:"EXPIRE_HOLDS_SCHEDULE"`
+	ScheduledTransfersSchedule string        `mapstructure:"SCHEDULED_TRANSFERS_SCHEDULE"`
+	AccrueInterestSchedule     string        `mapstructure:"ACCRUE_INTEREST_SCHEDULE"`
+	PostInterestSchedule       string        `mapstructure:"POST_INTEREST_SCHEDULE"`
+	MaintenanceFeesSchedule    string        `mapstructure:"MAINTENANCE_FEES_SCHEDULE"`
+	// Fraud rules are disabled while their thresholds are zero
+	RiskVelocityWindow          time.Duration `mapstructure:"RISK_VELOCITY_WINDOW"`
+	RiskVelocityReviewCount     int64         `mapstructure:"RISK_VELOCITY_REVIEW_COUNT"`
+	RiskVelocityBlockCount      int64         `mapstructure:"RISK_VELOCITY_BLOCK_COUNT"`
+	RiskNewPayeeBalancePercent  int64         `mapstructure:"RISK_NEW_PAYEE_BALANCE_PERCENT"`
+	RiskUnusualAmountMultiplier int64         `mapstructure:"RISK_UNUSUAL_AMOUNT_MULTIPLIER"`
+	RiskNewSessionWindow        time.Duration `mapstructure:"RISK_NEW_SESSION_WINDOW"`
+	RiskReviewHoldDuration      time.Duration `mapstructure:"RISK_REVIEW_HOLD_DURATION"`
+	// New payees can not be paid until their owner enters the code emailed by the worker
+	PayeeConfirmationRequired bool `mapstructure:"PAYEE_CONFIRMATION_REQUIRED"`
 }
 
 // LoadConfig reads configurations from file or environment variables
@@ -1,19 +1,10 @@
 package util
 
-// Constants for all supported currencies
+// Codes of the currencies seeded by the migrations
+// The supported currencies are managed by the currency registry
 const (
 	USD = "USD"
 	EUR = "EUR"
 	CAD = "CAD"
 	JPY = "JPY"
 )
-
-// IsSupportedCurrency returns true if the currency is supported
-func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case USD, EUR, CAD, JPY:
-		return true
-	}
-
-	return false
-}
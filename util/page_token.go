@@ -0,0 +1,47 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// pageCursor is the content of a page token, clients must treat the token as opaque
+type pageCursor struct {
+	AfterID int64 `json:"after_id"`
+}
+
+// EncodePageToken returns the token of the page starting right after the row with the given ID
+func EncodePageToken(afterID int64) string {
+	data, _ := json.Marshal(pageCursor{AfterID: afterID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageToken returns the ID of the last row of the previous page, an empty token points to the first page
+func DecodePageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.AfterID < 1 {
+		return 0, ErrInvalidPageToken
+	}
+
+	return cursor.AfterID, nil
+}
+
+// NextPageToken returns the token of the following page, or an empty string when the page is the last one
+func NextPageToken(lastID int64, count int, pageSize int32) string {
+	if count == 0 || count < int(pageSize) {
+		return ""
+	}
+	return EncodePageToken(lastID)
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	afterID := RandomInt(1, 1000)
+
+	token := EncodePageToken(afterID)
+	require.NotEmpty(t, token)
+
+	decoded, err := DecodePageToken(token)
+	require.NoError(t, err)
+	require.Equal(t, afterID, decoded)
+
+	decoded, err = DecodePageToken("")
+	require.NoError(t, err)
+	require.Zero(t, decoded)
+
+	for _, token := range []string{"not a token", EncodePageToken(0), EncodePageToken(-1)} {
+		_, err = DecodePageToken(token)
+		require.ErrorIs(t, err, ErrInvalidPageToken)
+	}
+}
+
+func TestNextPageToken(t *testing.T) {
+	require.Empty(t, NextPageToken(0, 0, 5))
+	require.Empty(t, NextPageToken(10, 3, 5))
+	require.Equal(t, EncodePageToken(10), NextPageToken(10, 5, 5))
+}
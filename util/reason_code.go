@@ -0,0 +1,25 @@
+package util
+
+// Reason codes of the deposits and withdrawals made by bankers
+const (
+	CashReason       = "cash"
+	ChequeReason     = "cheque"
+	WireReason       = "wire"
+	CorrectionReason = "correction"
+)
+
+// Reason codes of the transfers made by the bank itself, bankers can not use them
+const (
+	InterestReason = "interest"
+	FeeReason      = "fee"
+)
+
+// IsSupportedReasonCode returns true if the reason code is supported
+func IsSupportedReasonCode(code string) bool {
+	switch code {
+	case CashReason, ChequeReason, WireReason, CorrectionReason:
+		return true
+	}
+
+	return false
+}
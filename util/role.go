@@ -2,5 +2,7 @@ package util
 
 const (
 	DepositorRole = "depositor"
-	BankerRole = "banker"
-)
\ No newline at end of file
+	BankerRole    = "banker"
+	// Owns the internal accounts of the ledger, can not log in
+	SystemRole = "system"
+)
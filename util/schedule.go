@@ -0,0 +1,63 @@
+package util
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// MinRecurrenceInterval is the shortest interval allowed between two runs of a recurring transfer
+const MinRecurrenceInterval = 24 * time.Hour
+
+// recurrenceSamples is the number of consecutive runs checked against MinRecurrenceInterval
+const recurrenceSamples = 32
+
+var (
+	ErrInvalidRecurrence  = errors.New("invalid recurrence")
+	ErrRecurrenceTooOften = errors.New("recurrence runs more than once a day")
+)
+
+// ValidateRecurrence checks a standard 5-field cron expression, evaluated in UTC
+// The expression must fire at least once and never twice within MinRecurrenceInterval
+func ValidateRecurrence(recurrence string) error {
+	schedule, err := cron.ParseStandard(recurrence)
+	if err != nil {
+		return ErrInvalidRecurrence
+	}
+
+	// Start from a fixed date, so the same expression is always accepted or rejected
+	last := schedule.Next(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if last.IsZero() {
+		return ErrInvalidRecurrence
+	}
+
+	for i := 1; i < recurrenceSamples; i++ {
+		next := schedule.Next(last)
+		if next.IsZero() {
+			break
+		}
+
+		if next.Sub(last) < MinRecurrenceInterval {
+			return ErrRecurrenceTooOften
+		}
+		last = next
+	}
+
+	return nil
+}
+
+// NextRecurrence returns the first run of the recurrence strictly after t
+func NextRecurrence(recurrence string, t time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(recurrence)
+	if err != nil {
+		return time.Time{}, ErrInvalidRecurrence
+	}
+
+	next := schedule.Next(t.UTC())
+	if next.IsZero() {
+		return time.Time{}, ErrInvalidRecurrence
+	}
+
+	return next, nil
+}
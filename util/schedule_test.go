@@ -0,0 +1,38 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRecurrence(t *testing.T) {
+	for _, recurrence := range []string{"0 9 1 * *", "30 8 * * 1-5", "@daily", "0 0 29 2 *"} {
+		require.NoError(t, ValidateRecurrence(recurrence), recurrence)
+	}
+
+	for _, recurrence := range []string{"", "not a recurrence", "0 9 30 2 *", "0 9 * * * *"} {
+		require.ErrorIs(t, ValidateRecurrence(recurrence), ErrInvalidRecurrence, recurrence)
+	}
+
+	for _, recurrence := range []string{"* * * * *", "0 9,17 * * *", "@hourly", "@every 1h"} {
+		require.ErrorIs(t, ValidateRecurrence(recurrence), ErrRecurrenceTooOften, recurrence)
+	}
+}
+
+func TestNextRecurrence(t *testing.T) {
+	from := time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC)
+
+	next, err := NextRecurrence("0 9 1 * *", from)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC), next)
+
+	// The run at t itself is skipped
+	next, err = NextRecurrence("0 10 * * *", from)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.February, 1, 10, 0, 0, 0, time.UTC), next)
+
+	_, err = NextRecurrence("not a recurrence", from)
+	require.ErrorIs(t, err, ErrInvalidRecurrence)
+}
@@ -1,14 +1,21 @@
 package val
 
 import (
+	"errors"
 	"fmt"
 	"net/mail"
 	"regexp"
+	"time"
+
+	"github.com/hoangtk0100/simple-bank/currency"
+	"github.com/hoangtk0100/simple-bank/util"
 )
 
 var (
-	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
-	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidUsername     = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
+	isValidFullName     = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidCurrencyCode = regexp.MustCompile(`^[A-Z]{3}$`).MatchString
+	isValidProductCode  = regexp.MustCompile(`^[a-z_]+$`).MatchString
 )
 
 func ValidateString(value string, minLength int, maxLength int) error {
@@ -63,3 +70,196 @@ func ValidateEmailId(value int64) error {
 func ValidateSecretCode(value string) error {
 	return ValidateString(value, 32, 128)
 }
+
+func ValidatePayeeConfirmationCode(value string) error {
+	return ValidateString(value, 8, 8)
+}
+
+func ValidateAccountID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be positive integer")
+	}
+	return nil
+}
+
+func ValidateTransferID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be positive integer")
+	}
+	return nil
+}
+
+func ValidateScheduledTransferID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be positive integer")
+	}
+	return nil
+}
+
+func ValidateRiskDecisionID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be positive integer")
+	}
+	return nil
+}
+
+func ValidateTransferBatchID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be positive integer")
+	}
+	return nil
+}
+
+func ValidatePayeeID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be positive integer")
+	}
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateNonNegativeAmount(value int64) error {
+	if value < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !currency.IsSupported(value) {
+		return fmt.Errorf("is not a supported currency")
+	}
+	return nil
+}
+
+func ValidateCurrencyCode(value string) error {
+	if !isValidCurrencyCode(value) {
+		return fmt.Errorf("must be a 3-letter uppercase ISO 4217 code")
+	}
+	return nil
+}
+
+func ValidateCurrencyNumericCode(value int32) error {
+	if value < 1 || value > 999 {
+		return fmt.Errorf("must be from %d-%d", 1, 999)
+	}
+	return nil
+}
+
+func ValidateCurrencyExponent(value int32) error {
+	if value < 0 || value > 4 {
+		return fmt.Errorf("must be from %d-%d", 0, 4)
+	}
+	return nil
+}
+
+func ValidateCurrencySymbol(value string) error {
+	return ValidateString(value, 1, 10)
+}
+
+func ValidateProductCode(value string) error {
+	if err := ValidateString(value, 1, 32); err != nil {
+		return err
+	}
+	if !isValidProductCode(value) {
+		return fmt.Errorf("must contain only lowercase letters or underscore")
+	}
+	return nil
+}
+
+func ValidateIdempotencyKey(value string) error {
+	return ValidateString(value, 1, 255)
+}
+
+func ValidatePageID(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("must be positive integer")
+	}
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 100 {
+		return fmt.Errorf("must be from %d-%d", 5, 100)
+	}
+	return nil
+}
+
+func ValidatePageToken(value string) error {
+	if _, err := util.DecodePageToken(value); err != nil {
+		return fmt.Errorf("is not a valid page token")
+	}
+	return nil
+}
+
+func ValidateReasonCode(value string) error {
+	if !util.IsSupportedReasonCode(value) {
+		return fmt.Errorf("is not a supported reason code")
+	}
+	return nil
+}
+
+func ValidateExternalReference(value string) error {
+	return ValidateString(value, 1, 64)
+}
+
+func ValidateMemo(value string) error {
+	return ValidateString(value, 1, 255)
+}
+
+func ValidateMetadata(value map[string]string) error {
+	if len(value) > 20 {
+		return fmt.Errorf("must contain at most 20 keys")
+	}
+
+	for key, item := range value {
+		if err := ValidateString(key, 1, 40); err != nil {
+			return fmt.Errorf("key %q %w", key, err)
+		}
+
+		if len(item) > 500 {
+			return fmt.Errorf("value of key %q must contain at most 500 characters", key)
+		}
+	}
+	return nil
+}
+
+func ValidatePayeeNickname(value string) error {
+	return ValidateString(value, 1, 50)
+}
+
+// ValidateAccountMemberRole only accepts the roles a member can be invited with, an account has a single owner
+func ValidateAccountMemberRole(value string) error {
+	switch value {
+	case "co_owner", "spender", "viewer":
+		return nil
+	}
+	return fmt.Errorf("must be one of co_owner, spender or viewer")
+}
+
+func ValidateStatusReason(value string) error {
+	return ValidateString(value, 1, 255)
+}
+
+func ValidateRecurrence(value string) error {
+	if err := util.ValidateRecurrence(value); err != nil {
+		if errors.Is(err, util.ErrRecurrenceTooOften) {
+			return fmt.Errorf("must not run more than once a day")
+		}
+		return fmt.Errorf("is not a valid cron expression")
+	}
+	return nil
+}
+
+func ValidateFutureTime(value time.Time) error {
+	if !value.After(time.Now()) {
+		return fmt.Errorf("must be in the future")
+	}
+	return nil
+}
@@ -12,6 +12,21 @@ type TaskDistributor interface {
 		payload *PayloadSendVerifyEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskRunScheduledTransfer(
+		ctx context.Context,
+		payload *PayloadRunScheduledTransfer,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskProcessTransferBatch(
+		ctx context.Context,
+		payload *PayloadProcessTransferBatch,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendPayeeConfirmationEmail(
+		ctx context.Context,
+		payload *PayloadSendPayeeConfirmationEmail,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
@@ -36,6 +36,63 @@ func (m *MockTaskDistributor) EXPECT() *MockTaskDistributorMockRecorder {
 	return m.recorder
 }
 
+// DistributeTaskProcessTransferBatch mocks base method.
+func (m *MockTaskDistributor) DistributeTaskProcessTransferBatch(arg0 context.Context, arg1 *worker.PayloadProcessTransferBatch, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskProcessTransferBatch", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskProcessTransferBatch indicates an expected call of DistributeTaskProcessTransferBatch.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskProcessTransferBatch(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskProcessTransferBatch", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskProcessTransferBatch), varargs...)
+}
+
+// DistributeTaskRunScheduledTransfer mocks base method.
+func (m *MockTaskDistributor) DistributeTaskRunScheduledTransfer(arg0 context.Context, arg1 *worker.PayloadRunScheduledTransfer, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskRunScheduledTransfer", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskRunScheduledTransfer indicates an expected call of DistributeTaskRunScheduledTransfer.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskRunScheduledTransfer(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskRunScheduledTransfer", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskRunScheduledTransfer), varargs...)
+}
+
+// DistributeTaskSendPayeeConfirmationEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendPayeeConfirmationEmail(arg0 context.Context, arg1 *worker.PayloadSendPayeeConfirmationEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendPayeeConfirmationEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendPayeeConfirmationEmail indicates an expected call of DistributeTaskSendPayeeConfirmationEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendPayeeConfirmationEmail(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendPayeeConfirmationEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendPayeeConfirmationEmail), varargs...)
+}
+
 // DistributeTaskSendVerifyEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendVerifyEmail(arg0 context.Context, arg1 *worker.PayloadSendVerifyEmail, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -19,15 +19,26 @@ const (
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskReconcileAccounts(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExpireHolds(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDispatchScheduledTransfers(ctx context.Context, task *asynq.Task) error
+	ProcessTaskRunScheduledTransfer(ctx context.Context, task *asynq.Task) error
+	ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskChargeMaintenanceFees(ctx context.Context, task *asynq.Task) error
+	ProcessTaskProcessTransferBatch(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendPayeeConfirmationEmail(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
 	server *asynq.Server
 	store  db.Store
 	mailer mail.EmailSender
+	// Used by the tasks which enqueue other tasks
+	distributor TaskDistributor
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender, distributor TaskDistributor) TaskProcessor {
 	server := asynq.NewServer(
 		redisOpt,
 		asynq.Config{
@@ -44,9 +55,10 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer
 	)
 
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
+		server:      server,
+		store:       store,
+		mailer:      mailer,
+		distributor: distributor,
 	}
 }
 
@@ -54,6 +66,15 @@ func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskReconcileAccounts, processor.ProcessTaskReconcileAccounts)
+	mux.HandleFunc(TaskExpireHolds, processor.ProcessTaskExpireHolds)
+	mux.HandleFunc(TaskDispatchScheduledTransfers, processor.ProcessTaskDispatchScheduledTransfers)
+	mux.HandleFunc(TaskRunScheduledTransfer, processor.ProcessTaskRunScheduledTransfer)
+	mux.HandleFunc(TaskAccrueInterest, processor.ProcessTaskAccrueInterest)
+	mux.HandleFunc(TaskPostInterest, processor.ProcessTaskPostInterest)
+	mux.HandleFunc(TaskChargeMaintenanceFees, processor.ProcessTaskChargeMaintenanceFees)
+	mux.HandleFunc(TaskProcessTransferBatch, processor.ProcessTaskProcessTransferBatch)
+	mux.HandleFunc(TaskSendPayeeConfirmationEmail, processor.ProcessTaskSendPayeeConfirmationEmail)
 
 	return processor.server.Start(mux)
 }
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+type TaskScheduler interface {
+	Start() error
+}
+
+// ScheduleConfig contains the cron specs of the periodic tasks, an empty spec disables the task
+type ScheduleConfig struct {
+	ReconcileAccounts string
+	ExpireHolds       string
+	// Dispatches the scheduled transfers which are due, its interval is the delay before a due transfer runs
+	DispatchScheduledTransfers string
+	// Accrues the interest of the previous day, it should run daily shortly after midnight UTC
+	AccrueInterest string
+	// Pays the accrued interest, it should run monthly on the first day
+	PostInterest string
+	// Charges the maintenance fees of the previous month, it should run monthly on the first day
+	ChargeMaintenanceFees string
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+	config    ScheduleConfig
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt, config ScheduleConfig) TaskScheduler {
+	scheduler := asynq.NewScheduler(
+		redisOpt,
+		&asynq.SchedulerOpts{
+			Location: time.UTC,
+			EnqueueErrorHandler: func(task *asynq.Task, opts []asynq.Option, err error) {
+				log.Error().Err(err).Str("type", task.Type()).Msg("enqueue periodic task failed")
+			},
+			Logger: NewLogger(),
+		},
+	)
+
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+		config:    config,
+	}
+}
+
+func (scheduler *RedisTaskScheduler) Start() error {
+	// Every replica runs a scheduler, Unique drops the copies enqueued by the others
+	err := scheduler.register(scheduler.config.ReconcileAccounts, TaskReconcileAccounts, asynq.Queue(QueueLow), asynq.Unique(time.Hour))
+	if err != nil {
+		return err
+	}
+
+	err = scheduler.register(scheduler.config.ExpireHolds, TaskExpireHolds, asynq.Queue(QueueDefault), asynq.Unique(time.Minute))
+	if err != nil {
+		return err
+	}
+
+	err = scheduler.register(scheduler.config.DispatchScheduledTransfers, TaskDispatchScheduledTransfers, asynq.Queue(QueueCritical), asynq.Unique(time.Minute))
+	if err != nil {
+		return err
+	}
+
+	err = scheduler.register(scheduler.config.AccrueInterest, TaskAccrueInterest, asynq.Queue(QueueLow), asynq.Unique(time.Hour))
+	if err != nil {
+		return err
+	}
+
+	err = scheduler.register(scheduler.config.PostInterest, TaskPostInterest, asynq.Queue(QueueLow), asynq.Unique(time.Hour))
+	if err != nil {
+		return err
+	}
+
+	err = scheduler.register(scheduler.config.ChargeMaintenanceFees, TaskChargeMaintenanceFees, asynq.Queue(QueueLow), asynq.Unique(time.Hour))
+	if err != nil {
+		return err
+	}
+
+	return scheduler.scheduler.Start()
+}
+
+func (scheduler *RedisTaskScheduler) register(cronspec string, taskType string, opts ...asynq.Option) error {
+	if cronspec == "" {
+		return nil
+	}
+
+	_, err := scheduler.scheduler.Register(cronspec, asynq.NewTask(taskType, nil, opts...))
+	if err != nil {
+		return fmt.Errorf("failed to register periodic task %s: %w", taskType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskAccrueInterest = "task:accrue_interest"
+
+// interestAccountsPageSize is the number of accounts read at once by the interest tasks
+const interestAccountsPageSize = 100
+
+// ProcessTaskAccrueInterest records the interest earned yesterday by every interest bearing account
+// Each account is accrued in its own transaction, a failed run is safe to retry as the accrued days are skipped
+func (processor *RedisTaskProcessor) ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error {
+	through := lastClosedDay(time.Now())
+	accruals := 0
+
+	err := processor.forEachInterestBearingAccount(ctx, func(account db.Account) error {
+		result, err := processor.store.AccrueInterestTx(ctx, db.AccrueInterestTxParams{
+			AccountID: account.ID,
+			Through:   through,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to accrue interest of account %d: %w", account.ID, err)
+		}
+
+		accruals += len(result.Accruals)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Time("through", through).
+		Int("accruals", accruals).
+		Msg("processed task")
+
+	return nil
+}
+
+// forEachInterestBearingAccount calls process with every account which earns interest, one page of accounts at a time
+func (processor *RedisTaskProcessor) forEachInterestBearingAccount(ctx context.Context, process func(account db.Account) error) error {
+	var afterID int64
+
+	for {
+		accounts, err := processor.store.ListInterestBearingAccounts(ctx, db.ListInterestBearingAccountsParams{
+			AfterID: afterID,
+			Limit:   interestAccountsPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list interest bearing accounts: %w", err)
+		}
+
+		for _, account := range accounts {
+			err = process(account)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(accounts) < interestAccountsPageSize {
+			return nil
+		}
+
+		afterID = accounts[len(accounts)-1].ID
+	}
+}
+
+// lastClosedDay returns the UTC day before now, the last one whose closing balances are known
+func lastClosedDay(now time.Time) time.Time {
+	return now.UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+}
@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskChargeMaintenanceFees = "task:charge_maintenance_fees"
+
+// feeAccountsPageSize is the number of accounts read at once by the maintenance fees task
+const feeAccountsPageSize = 100
+
+// ProcessTaskChargeMaintenanceFees charges the monthly and below minimum fees of the previous month to every account with a fee schedule
+// It is scheduled on the first day of the month, a failed run is safe to retry as the fees already charged are skipped
+func (processor *RedisTaskProcessor) ProcessTaskChargeMaintenanceFees(ctx context.Context, task *asynq.Task) error {
+	period := lastClosedDay(time.Now())
+	var afterID int64
+	fees := 0
+
+	for {
+		accounts, err := processor.store.ListFeeBearingAccounts(ctx, db.ListFeeBearingAccountsParams{
+			AfterID: afterID,
+			Limit:   feeAccountsPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list fee bearing accounts: %w", err)
+		}
+
+		for _, account := range accounts {
+			result, err := processor.store.ChargeMaintenanceFeesTx(ctx, db.ChargeMaintenanceFeesTxParams{
+				AccountID: account.ID,
+				Period:    period,
+			})
+			if err != nil {
+				// The account was frozen after it was listed, the other accounts are still charged
+				if errors.Is(err, db.ErrAccountNotActive) {
+					log.Warn().
+						Str("type", task.Type()).
+						Int64("account_id", account.ID).
+						Msg("maintenance fees of inactive account are not charged")
+					continue
+				}
+				return fmt.Errorf("failed to charge maintenance fees of account %d: %w", account.ID, err)
+			}
+
+			fees += len(result.Fees)
+		}
+
+		if len(accounts) < feeAccountsPageSize {
+			break
+		}
+
+		afterID = accounts[len(accounts)-1].ID
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Time("period", period).
+		Int("fees", fees).
+		Msg("processed task")
+
+	return nil
+}
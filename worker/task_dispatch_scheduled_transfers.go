@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskDispatchScheduledTransfers = "task:dispatch_scheduled_transfers"
+
+// maxDispatchedTransfers limits the work of one run, the remaining due transfers are dispatched by the next runs
+const maxDispatchedTransfers = 100
+
+// ProcessTaskDispatchScheduledTransfers enqueues a run for every due scheduled transfer, then moves it to its next date
+// The task ID and the unique run per date make a transfer dispatched twice run only once
+func (processor *RedisTaskProcessor) ProcessTaskDispatchScheduledTransfers(ctx context.Context, task *asynq.Task) error {
+	scheduledTransfers, err := processor.store.ListDueScheduledTransfers(ctx, maxDispatchedTransfers)
+	if err != nil {
+		return fmt.Errorf("failed to list due scheduled transfers: %w", err)
+	}
+
+	for _, scheduledTransfer := range scheduledTransfers {
+		err = processor.dispatchScheduledTransfer(ctx, scheduledTransfer)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int("scheduled_transfers", len(scheduledTransfers)).
+		Msg("processed task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) dispatchScheduledTransfer(ctx context.Context, scheduledTransfer db.ScheduledTransfer) error {
+	scheduledAt := scheduledTransfer.NextRunAt.Time
+
+	err := processor.distributor.DistributeTaskRunScheduledTransfer(
+		ctx,
+		&PayloadRunScheduledTransfer{
+			ScheduledTransferID: scheduledTransfer.ID,
+			ScheduledAt:         scheduledAt,
+		},
+		asynq.TaskID(fmt.Sprintf("scheduled_transfer:%d:%d", scheduledTransfer.ID, scheduledAt.Unix())),
+		asynq.MaxRetry(5),
+		asynq.Queue(QueueCritical),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return fmt.Errorf("failed to distribute task to run scheduled transfer: %w", err)
+	}
+
+	arg := db.UpdateScheduledTransferParams{
+		ID: scheduledTransfer.ID,
+	}
+
+	if scheduledTransfer.Recurrence.Valid {
+		// Runs missed while the worker was down are skipped instead of being performed in a burst
+		nextRunAt, err := util.NextRecurrence(scheduledTransfer.Recurrence.String, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to compute next run of scheduled transfer %d: %w", scheduledTransfer.ID, err)
+		}
+
+		arg.NextRunAt = pgtype.Timestamptz{
+			Time:  nextRunAt,
+			Valid: true,
+		}
+	} else {
+		// The run completes or fails the one-off transfer
+		arg.Status = pgtype.Text{
+			String: db.ScheduledTransferStatusDispatched,
+			Valid:  true,
+		}
+		arg.ClearNextRunAt = true
+	}
+
+	_, err = processor.store.UpdateScheduledTransfer(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled transfer: %w", err)
+	}
+
+	return nil
+}
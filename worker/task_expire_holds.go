@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskExpireHolds = "task:expire_holds"
+
+// ProcessTaskExpireHolds releases the holds which expired before being captured
+// A failed run is safe to retry, the holds released before the failure are skipped
+func (processor *RedisTaskProcessor) ProcessTaskExpireHolds(ctx context.Context, task *asynq.Task) error {
+	result, err := processor.store.ExpireHolds(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to expire holds: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int("holds", len(result.Holds)).
+		Msg("processed task")
+
+	return nil
+}
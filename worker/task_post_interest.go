@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPostInterest = "task:post_interest"
+
+// ProcessTaskPostInterest pays the interest accrued by every interest bearing account up to yesterday
+// It is scheduled on the first day of the month, so the payment covers the month which just ended
+// A failed run is safe to retry, the accruals paid before the failure are skipped
+func (processor *RedisTaskProcessor) ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error {
+	through := lastClosedDay(time.Now())
+	transfers := 0
+
+	err := processor.forEachInterestBearingAccount(ctx, func(account db.Account) error {
+		result, err := processor.store.PostInterestTx(ctx, db.PostInterestTxParams{
+			AccountID: account.ID,
+			Through:   through,
+		})
+		if err != nil {
+			// Frozen accounts can not receive money, their interest is paid once they are unfrozen
+			if errors.Is(err, db.ErrAccountNotActive) {
+				log.Warn().
+					Str("type", task.Type()).
+					Int64("account_id", account.ID).
+					Msg("interest of inactive account is not posted")
+				return nil
+			}
+			return fmt.Errorf("failed to post interest of account %d: %w", account.ID, err)
+		}
+
+		if result.Transfer.ID != 0 {
+			transfers++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Time("through", through).
+		Int("transfers", transfers).
+		Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskProcessTransferBatch = "task:process_transfer_batch"
+
+type PayloadProcessTransferBatch struct {
+	BatchID int64 `json:"batch_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskProcessTransferBatch(
+	ctx context.Context,
+	payload *PayloadProcessTransferBatch,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskProcessTransferBatch, jsonPayload, opts...)
+	infor, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", infor.Queue).
+		Int("max_retry", infor.MaxRetry).
+		Msg("enqueue task")
+
+	return nil
+}
+
+// ProcessTaskProcessTransferBatch performs the transfers of the pending rows of a batch in order, then completes the batch
+// A transfer rejected by the bank rules fails its row and the next rows go on, other errors are retried
+// from the first pending row. Once the retries are exhausted the row is failed so the batch still completes
+func (processor *RedisTaskProcessor) ProcessTaskProcessTransferBatch(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadProcessTransferBatch
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	batch, err := processor.store.GetTransferBatch(ctx, payload.BatchID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("transfer batch doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get transfer batch: %w", err)
+	}
+
+	if batch.Status == db.TransferBatchStatusCompleted {
+		return nil
+	}
+
+	rows, err := processor.store.ListTransferBatchRows(ctx, batch.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list transfer batch rows: %w", err)
+	}
+
+	for _, row := range rows {
+		if row.Status != db.TransferBatchRowStatusPending {
+			continue
+		}
+
+		err = processor.runTransferBatchRow(ctx, row)
+		if err != nil {
+			return err
+		}
+	}
+
+	batch, err = processor.store.CompleteTransferBatch(ctx, batch.ID)
+	if err != nil {
+		return fmt.Errorf("failed to complete transfer batch: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Int32("succeeded_count", batch.SucceededCount).
+		Int32("failed_count", batch.FailedCount).
+		Msg("processed task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) runTransferBatchRow(ctx context.Context, row db.TransferBatchRow) error {
+	_, err := processor.store.RunTransferBatchRowTx(ctx, db.RunTransferBatchRowTxParams{
+		RowID: row.ID,
+	})
+
+	switch {
+	case err == nil, errors.Is(err, db.ErrTransferBatchRowProcessed):
+		return nil
+	case isRejectedTransfer(err):
+		return processor.failTransferBatchRow(ctx, row, err.Error())
+	}
+
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	if retried >= maxRetry {
+		return processor.failTransferBatchRow(ctx, row, "internal error")
+	}
+
+	return fmt.Errorf("failed to run transfer batch row %d: %w", row.RowNumber, err)
+}
+
+func (processor *RedisTaskProcessor) failTransferBatchRow(ctx context.Context, row db.TransferBatchRow, reason string) error {
+	_, err := processor.store.FinishTransferBatchRow(ctx, db.FinishTransferBatchRowParams{
+		ID:     row.ID,
+		Status: db.TransferBatchRowStatusFailed,
+		Error: pgtype.Text{
+			String: reason,
+			Valid:  true,
+		},
+	})
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		// No row is returned when the row was processed meanwhile
+		return fmt.Errorf("failed to mark transfer batch row %d as failed: %w", row.RowNumber, err)
+	}
+
+	return nil
+}
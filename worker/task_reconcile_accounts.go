@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskReconcileAccounts = "task:reconcile_accounts"
+
+// maxAlertedReports limits the size of the alert email, the full list is available through the API
+const maxAlertedReports = 50
+
+func (processor *RedisTaskProcessor) ProcessTaskReconcileAccounts(ctx context.Context, task *asynq.Task) error {
+	result, err := processor.store.ReconcileAccountsTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile accounts: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int("discrepancies", len(result.Reports)).
+		Msg("reconciled accounts")
+
+	if len(result.Reports) == 0 {
+		return nil
+	}
+
+	bankers, err := processor.store.ListUsersByRole(ctx, util.BankerRole)
+	if err != nil {
+		return fmt.Errorf("failed to list bankers: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// Reports must not leak to addresses which are not proven to belong to the bankers
+	to := make([]string, 0, len(bankers))
+	for _, banker := range bankers {
+		if banker.IsEmailVerified {
+			to = append(to, banker.Email)
+		}
+	}
+
+	if len(to) == 0 {
+		log.Warn().
+			Str("type", task.Type()).
+			Msg("no verified banker to alert about the reconciliation discrepancies")
+		return nil
+	}
+
+	subject := fmt.Sprintf("Simple Bank reconciliation: %d account(s) out of balance", len(result.Reports))
+	content := reconciliationAlertContent(result.Reports)
+
+	// The reports are already stored, retrying would store them again
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send reconciliation alert: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int("bankers", len(to)).
+		Msg("processed task")
+
+	return nil
+}
+
+func reconciliationAlertContent(reports []db.ReconciliationReport) string {
+	var content strings.Builder
+
+	content.WriteString(`
+	Hello,<br/>
+	The balance of the following accounts doesn't match the sum of their entries (amounts in minor units):<br/>
+	<table>
+	<tr><th>Report</th><th>Account</th><th>Balance</th><th>Entries total</th><th>Difference</th></tr>
+	`)
+
+	for index, report := range reports {
+		if index == maxAlertedReports {
+			break
+		}
+
+		fmt.Fprintf(&content, "<tr><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td></tr>\n",
+			report.ID, report.AccountID, report.Balance, report.EntriesTotal, report.Difference)
+	}
+
+	content.WriteString("</table>\n")
+	if len(reports) > maxAlertedReports {
+		fmt.Fprintf(&content, "And %d more, see the reconciliation reports API.<br/>\n", len(reports)-maxAlertedReports)
+	}
+
+	return content.String()
+}
@@ -0,0 +1,149 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskRunScheduledTransfer = "task:run_scheduled_transfer"
+
+type PayloadRunScheduledTransfer struct {
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time `json:"scheduled_at"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskRunScheduledTransfer(
+	ctx context.Context,
+	payload *PayloadRunScheduledTransfer,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskRunScheduledTransfer, jsonPayload, opts...)
+	infor, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", infor.Queue).
+		Int("max_retry", infor.MaxRetry).
+		Msg("enqueue task")
+
+	return nil
+}
+
+// ProcessTaskRunScheduledTransfer performs one run of a scheduled transfer and records its outcome
+// A transfer rejected by the bank rules is recorded as a failed run and the owner is notified by email,
+// other errors are retried and the run is recorded as failed once the retries are exhausted
+func (processor *RedisTaskProcessor) ProcessTaskRunScheduledTransfer(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadRunScheduledTransfer
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	result, err := processor.store.RunScheduledTransferTx(ctx, db.RunScheduledTransferTxParams{
+		ScheduledTransferID: payload.ScheduledTransferID,
+		ScheduledAt:         payload.ScheduledAt,
+	})
+	if err == nil {
+		log.Info().
+			Str("type", task.Type()).
+			Bytes("payload", task.Payload()).
+			Int64("transfer_id", result.Transfer.Transfer.ID).
+			Msg("processed task")
+		return nil
+	}
+
+	switch {
+	case db.ErrorCode(err) == db.UniqueViolation:
+		// A duplicated task, the run was already recorded
+		return nil
+	case errors.Is(err, db.ErrScheduledTransferCancelled):
+		return nil
+	case errors.Is(err, db.ErrRecordNotFound):
+		return fmt.Errorf("scheduled transfer doesn't exist: %w", asynq.SkipRetry)
+	case isRejectedTransfer(err):
+		return processor.failScheduledTransferRun(ctx, payload, err.Error())
+	}
+
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	if retried >= maxRetry {
+		return processor.failScheduledTransferRun(ctx, payload, "internal error")
+	}
+
+	return fmt.Errorf("failed to run scheduled transfer: %w", err)
+}
+
+// isRejectedTransfer reports whether the transfer was refused by the bank rules, so retrying would not help
+func isRejectedTransfer(err error) bool {
+	return errors.Is(err, db.ErrInsufficientFunds) ||
+		errors.Is(err, db.ErrAccountNotActive) ||
+		errors.Is(err, db.ErrSystemAccount) ||
+		errors.Is(err, db.ErrTransferLimitExceeded) ||
+		errors.Is(err, db.ErrAccountAccessDenied) ||
+		errors.Is(err, db.ErrSpendLimitExceeded)
+}
+
+// failScheduledTransferRun records the failed run, which also fails a one-off transfer, and emails its owner
+func (processor *RedisTaskProcessor) failScheduledTransferRun(ctx context.Context, payload PayloadRunScheduledTransfer, reason string) error {
+	result, err := processor.store.FailScheduledTransferRunTx(ctx, db.FailScheduledTransferRunTxParams{
+		ScheduledTransferID: payload.ScheduledTransferID,
+		ScheduledAt:         payload.ScheduledAt,
+		Error:               reason,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			// The owner was notified when the run was recorded
+			return nil
+		}
+		return fmt.Errorf("failed to record failed scheduled transfer run: %w", err)
+	}
+
+	scheduledTransfer := result.ScheduledTransfer
+	next := "The next runs are not affected, please make sure the account can cover them."
+	if !scheduledTransfer.Recurrence.Valid {
+		next = "The transfer will not be retried, please schedule it again if it is still needed."
+	}
+
+	user, err := processor.store.GetUser(ctx, scheduledTransfer.Owner)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %v: %w", err, asynq.SkipRetry)
+	}
+
+	subject := "Simple Bank: a scheduled transfer failed"
+	content := fmt.Sprintf(`
+	Hello %s,<br/>
+	Your scheduled transfer #%d of %d (minor units) from account %d to account %d due on %s failed: %s.<br/>
+	%s<br/>
+	`, user.FullName, scheduledTransfer.ID, scheduledTransfer.Amount, scheduledTransfer.FromAccountID,
+		scheduledTransfer.ToAccountID, payload.ScheduledAt.UTC().Format(time.RFC1123), reason, next)
+	to := []string{user.Email}
+
+	// The run is already recorded, retrying would not record it again nor send the email
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send scheduled transfer failure email: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log.Info().
+		Int64("scheduled_transfer_id", payload.ScheduledTransferID).
+		Str("reason", reason).
+		Str("email", user.Email).
+		Msg("scheduled transfer run failed")
+
+	return nil
+}
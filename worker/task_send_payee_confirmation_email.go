@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/hoangtk0100/simple-bank/db/sqlc"
+	"github.com/hoangtk0100/simple-bank/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendPayeeConfirmationEmail = "task:send_payee_confirmation_email"
+
+const (
+	PayeeConfirmationCodeLength   = 8
+	payeeConfirmationCodeDuration = 15 * time.Minute
+)
+
+type PayloadSendPayeeConfirmationEmail struct {
+	PayeeID int64 `json:"payee_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendPayeeConfirmationEmail(
+	ctx context.Context,
+	payload *PayloadSendPayeeConfirmationEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendPayeeConfirmationEmail, jsonPayload, opts...)
+	infor, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", infor.Queue).
+		Int("max_retry", infor.MaxRetry).
+		Msg("enqueue task")
+
+	return nil
+}
+
+// ProcessTaskSendPayeeConfirmationEmail emails a new confirmation code of a pending payee to its owner
+// The code is only stored on the payee, so a retry replaces the code of the previous attempt
+func (processor *RedisTaskProcessor) ProcessTaskSendPayeeConfirmationEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPayeeConfirmationEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	payee, err := processor.store.SetPayeeConfirmationCode(ctx, db.SetPayeeConfirmationCodeParams{
+		ID: payload.PayeeID,
+		ConfirmationCode: pgtype.Text{
+			String: util.RandomString(PayeeConfirmationCodeLength),
+			Valid:  true,
+		},
+		ConfirmationExpiresAt: pgtype.Timestamptz{
+			Time:  time.Now().Add(payeeConfirmationCodeDuration),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		// No row is returned when the payee was confirmed or deleted meanwhile
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("payee is not pending: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to set payee confirmation code: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, payee.Owner)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Confirm your new payee"
+	content := fmt.Sprintf(`
+	Hello %s,<br/>
+	A new payee <b>%s</b> was added to your account.<br/>
+	Enter the code <b>%s</b> within %d minutes to confirm it.<br/>
+	If you did not add this payee, please contact us right away.<br/>
+	`, user.FullName, payee.Nickname, payee.ConfirmationCode.String, int(payeeConfirmationCodeDuration.Minutes()))
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send payee confirmation email: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", user.Email).
+		Msg("processed task")
+
+	return nil
+}